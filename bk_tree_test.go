@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBKNodeSearchFindsWithinDistance(t *testing.T) {
+	// A small, hand-picked set of normalized names with known edit
+	// distances from "bandung": "bandung" itself (0), "bandun" (1, one
+	// deletion), "bandurg" (1, one substitution), "jakarta" (far away).
+	names := []string{"bandung", "bandun", "bandurg", "jakarta", "bandungbarat"}
+
+	var root *bkNode
+	for i, n := range names {
+		bkInsert(&root, i, n)
+	}
+
+	var matches []bkMatch
+	root.search("bandung", 1, &matches)
+
+	got := make(map[int]int, len(matches))
+	for _, m := range matches {
+		got[m.idx] = m.distance
+	}
+
+	want := map[int]int{0: 0, 1: 1, 2: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("search(%q, maxD=1) = %v, want %v", "bandung", got, want)
+	}
+}
+
+func TestBKNodeSearchZeroDistanceIsExactMatchOnly(t *testing.T) {
+	names := []string{"bandung", "bandun", "jakarta"}
+	var root *bkNode
+	for i, n := range names {
+		bkInsert(&root, i, n)
+	}
+
+	var matches []bkMatch
+	root.search("bandung", 0, &matches)
+	if len(matches) != 1 || matches[0].idx != 0 || matches[0].distance != 0 {
+		t.Fatalf("expected exactly the exact match, got %+v", matches)
+	}
+}
+
+func TestBKNodeInsertSkipsExactDuplicate(t *testing.T) {
+	var root *bkNode
+	bkInsert(&root, 0, "bandung")
+	bkInsert(&root, 1, "bandung")
+
+	var matches []bkMatch
+	root.search("bandung", 0, &matches)
+
+	ids := make([]int, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, m.idx)
+	}
+	sort.Ints(ids)
+
+	// insert treats a zero-distance name as already reachable from the
+	// node it collided with and doesn't add a second node for it, so only
+	// the first-inserted idx is ever found.
+	if !reflect.DeepEqual(ids, []int{0}) {
+		t.Fatalf("expected duplicate insert to be a no-op, got idx %v", ids)
+	}
+}
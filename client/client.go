@@ -0,0 +1,141 @@
+// Package client is a thin typed wrapper over the wilayah.v1.* NATS
+// subjects (see main's natsV1Subjects), for other Go services in the mesh
+// that want to resolve region data without an HTTP round trip.
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// DefaultTimeout is how long a Client call waits for a NATS reply before
+// giving up.
+const DefaultTimeout = 5 * time.Second
+
+// Client issues wilayah.v1.* requests over an existing NATS connection; it
+// does not own conn's lifecycle, so callers are responsible for draining
+// or closing it.
+type Client struct {
+	conn    *nats.Conn
+	timeout time.Duration
+}
+
+// New wraps conn with DefaultTimeout.
+func New(conn *nats.Conn) *Client {
+	return &Client{conn: conn, timeout: DefaultTimeout}
+}
+
+// WithTimeout returns a copy of c that waits timeout for a reply instead of
+// DefaultTimeout.
+func (c *Client) WithTimeout(timeout time.Duration) *Client {
+	cp := *c
+	cp.timeout = timeout
+	return &cp
+}
+
+// Ref is an id/nama pair, e.g. one province or kabupaten.
+type Ref struct {
+	ID   string `json:"id"`
+	Nama string `json:"nama"`
+}
+
+// LookupItem is one requested code's resolution in a BatchLookup result.
+type LookupItem struct {
+	Info  map[string]interface{} `json:"info,omitempty"`
+	Error string                 `json:"error,omitempty"`
+}
+
+// envelope mirrors main's v1Envelope: {data, error}, never both.
+type envelope struct {
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// request marshals payload (nil for subjects that take none), sends it to
+// subject, and decodes the reply's data field into out. A populated
+// envelope.Error comes back as a Go error instead.
+func (c *Client) request(subject string, payload, out interface{}) error {
+	var data []byte
+	if payload != nil {
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("client: marshal request for %s: %w", subject, err)
+		}
+	}
+
+	msg, err := c.conn.Request(subject, data, c.timeout)
+	if err != nil {
+		return fmt.Errorf("client: request %s: %w", subject, err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(msg.Data, &env); err != nil {
+		return fmt.Errorf("client: decode reply from %s: %w", subject, err)
+	}
+	if env.Error != "" {
+		return fmt.Errorf("client: %s: %s", subject, env.Error)
+	}
+	if out == nil || len(env.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("client: decode data from %s: %w", subject, err)
+	}
+	return nil
+}
+
+// GetProvinsi lists every province via wilayah.v1.provinsi.list.
+func (c *Client) GetProvinsi() ([]Ref, error) {
+	var refs []Ref
+	if err := c.request("wilayah.v1.provinsi.list", nil, &refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// GetKabupaten lists proID's kabupaten/kota via wilayah.v1.kabupaten.list.
+func (c *Client) GetKabupaten(proID string) ([]Ref, error) {
+	var refs []Ref
+	req := struct {
+		Pro string `json:"pro"`
+	}{Pro: proID}
+	if err := c.request("wilayah.v1.kabupaten.list", req, &refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// GetInfoByCode resolves a single BPS code (2/4/7/10 digits) via
+// wilayah.v1.info.
+func (c *Client) GetInfoByCode(code string) (map[string]interface{}, error) {
+	var info map[string]interface{}
+	req := struct {
+		Code string `json:"code"`
+	}{Code: code}
+	if err := c.request("wilayah.v1.info", req, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// BatchLookup resolves codes keyed by code via wilayah.v1.lookup.batch.
+// Duplicate codes in the request are deduped by the server.
+func (c *Client) BatchLookup(codes []string) (map[string]LookupItem, error) {
+	var result map[string]LookupItem
+	req := struct {
+		Codes []string `json:"codes"`
+	}{Codes: codes}
+	if err := c.request("wilayah.v1.lookup.batch", req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Health checks wilayah.v1.health, returning nil if the service replied
+// without an error.
+func (c *Client) Health() error {
+	return c.request("wilayah.v1.health", nil, nil)
+}
@@ -0,0 +1,208 @@
+// Package config loads wilayah.yml, the scraper's optional YAML (or TOML)
+// config file. CLI flags always take precedence over values loaded here, so
+// a config file can hold the defaults for a host while one-off runs still
+// override them.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Source is one upstream to scrape: a base URL plus the year(s) to archive.
+// Multiple sources let a single run pull (and keep side-by-side) historical
+// snapshots from more than one endpoint.
+type Source struct {
+	Name    string `yaml:"name" toml:"name"`
+	BaseURL string `yaml:"base_url" toml:"base_url"`
+	Years   []int  `yaml:"years" toml:"years"`
+}
+
+// Output controls how and where scrape results are written.
+type Output struct {
+	Formats []string `yaml:"formats" toml:"formats"`
+	Dir     string   `yaml:"dir" toml:"dir"`
+}
+
+// Checkpoint controls checkpoint retention.
+type Checkpoint struct {
+	KeepDays int `yaml:"keep_days" toml:"keep_days"`
+}
+
+// HTTP controls outbound request behaviour.
+type HTTP struct {
+	Timeout time.Duration `yaml:"timeout" toml:"timeout"`
+}
+
+// Profile overrides a subset of Config's fields for one named, reproducible
+// run, selected at the CLI with --profile=<name> (e.g. profiles.fast,
+// profiles.polite in scraper.yaml). Zero-valued fields leave the base Config
+// value untouched.
+type Profile struct {
+	MaxWorkers  int           `yaml:"max_workers" toml:"max_workers"`
+	BaseURL     string        `yaml:"base_url" toml:"base_url"`
+	Year        int           `yaml:"year" toml:"year"`
+	RateLimit   float64       `yaml:"rate_limit" toml:"rate_limit"`
+	Retries     int           `yaml:"retries" toml:"retries"`
+	BackoffBase time.Duration `yaml:"backoff_base" toml:"backoff_base"`
+	Formats     []string      `yaml:"formats" toml:"formats"`
+	KeepDays    int           `yaml:"keep_days" toml:"keep_days"`
+	Only        []string      `yaml:"only" toml:"only"`       // if set, only walk these provinsi IDs, e.g. ["11", "12"]
+	Exclude     []string      `yaml:"exclude" toml:"exclude"` // skip these provinsi IDs, applied after Only
+}
+
+// Config is the root of wilayah.yml (or scraper.toml).
+type Config struct {
+	Sources     []Source           `yaml:"sources" toml:"sources"`
+	Workers     int                `yaml:"workers" toml:"workers"`
+	RateLimit   float64            `yaml:"rate_limit" toml:"rate_limit"`
+	Retries     int                `yaml:"retries" toml:"retries"`
+	BackoffBase time.Duration      `yaml:"backoff_base" toml:"backoff_base"`
+	Output      Output             `yaml:"output" toml:"output"`
+	Checkpoint  Checkpoint         `yaml:"checkpoint" toml:"checkpoint"`
+	HTTP        HTTP               `yaml:"http" toml:"http"`
+	Only        []string           `yaml:"only" toml:"only"`
+	Exclude     []string           `yaml:"exclude" toml:"exclude"`
+	Profiles    map[string]Profile `yaml:"profiles" toml:"profiles"`
+}
+
+// Default returns a Config populated with the same defaults NewScraper would
+// otherwise hard-code, so a missing wilayah.yml behaves exactly like before
+// this package existed.
+func Default() *Config {
+	return &Config{
+		Sources: []Source{
+			{Name: "default", BaseURL: "https://sipedas.pertanian.go.id/api/wilayah/", Years: []int{time.Now().Year()}},
+		},
+		Workers:     4,
+		RateLimit:   5,
+		Retries:     3,
+		BackoffBase: 500 * time.Millisecond,
+		Output: Output{
+			Formats: []string{"json"},
+			Dir:     "scraper/output",
+		},
+		Checkpoint: Checkpoint{KeepDays: 7},
+		HTTP:       HTTP{Timeout: 10 * time.Second},
+	}
+}
+
+// Load reads and parses path, filling in any field left zero-valued with the
+// Default() value. A missing file is not an error: callers get Default().
+// The format is picked by extension: .toml parses as TOML, anything else
+// (including the conventional .yml/.yaml) parses as YAML.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading config %s: %v", path, err)
+	}
+
+	loaded := &Config{}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		if err := toml.Unmarshal(data, loaded); err != nil {
+			return nil, fmt.Errorf("error parsing config %s: %v", path, err)
+		}
+	} else if err := yaml.Unmarshal(data, loaded); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %v", path, err)
+	}
+
+	if len(loaded.Sources) > 0 {
+		cfg.Sources = loaded.Sources
+	}
+	if loaded.Workers > 0 {
+		cfg.Workers = loaded.Workers
+	}
+	if loaded.RateLimit > 0 {
+		cfg.RateLimit = loaded.RateLimit
+	}
+	if loaded.Retries > 0 {
+		cfg.Retries = loaded.Retries
+	}
+	if loaded.BackoffBase > 0 {
+		cfg.BackoffBase = loaded.BackoffBase
+	}
+	if len(loaded.Output.Formats) > 0 {
+		cfg.Output.Formats = loaded.Output.Formats
+	}
+	if loaded.Output.Dir != "" {
+		cfg.Output.Dir = loaded.Output.Dir
+	}
+	if loaded.Checkpoint.KeepDays > 0 {
+		cfg.Checkpoint.KeepDays = loaded.Checkpoint.KeepDays
+	}
+	if loaded.HTTP.Timeout > 0 {
+		cfg.HTTP.Timeout = loaded.HTTP.Timeout
+	}
+	if len(loaded.Only) > 0 {
+		cfg.Only = loaded.Only
+	}
+	if len(loaded.Exclude) > 0 {
+		cfg.Exclude = loaded.Exclude
+	}
+	if len(loaded.Profiles) > 0 {
+		cfg.Profiles = loaded.Profiles
+	}
+
+	return cfg, nil
+}
+
+// ApplyProfile looks up name in cfg.Profiles and overlays its non-zero
+// fields onto a copy of cfg, so `--config=scraper.yaml --profile=polite`
+// reproduces the same run every time. An unknown profile name is an error
+// rather than a silent no-op, since a typo'd --profile should not quietly
+// fall back to the base config.
+func ApplyProfile(cfg *Config, name string) (*Config, error) {
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("config: profile %q not found", name)
+	}
+
+	out := *cfg
+	if profile.MaxWorkers > 0 {
+		out.Workers = profile.MaxWorkers
+	}
+	if profile.BaseURL != "" || profile.Year > 0 {
+		source := out.Sources[0]
+		if profile.BaseURL != "" {
+			source.BaseURL = profile.BaseURL
+		}
+		if profile.Year > 0 {
+			source.Years = []int{profile.Year}
+		}
+		out.Sources = []Source{source}
+	}
+	if profile.RateLimit > 0 {
+		out.RateLimit = profile.RateLimit
+	}
+	if profile.Retries > 0 {
+		out.Retries = profile.Retries
+	}
+	if profile.BackoffBase > 0 {
+		out.BackoffBase = profile.BackoffBase
+	}
+	if len(profile.Formats) > 0 {
+		out.Output.Formats = profile.Formats
+	}
+	if profile.KeepDays > 0 {
+		out.Checkpoint.KeepDays = profile.KeepDays
+	}
+	if len(profile.Only) > 0 {
+		out.Only = profile.Only
+	}
+	if len(profile.Exclude) > 0 {
+		out.Exclude = profile.Exclude
+	}
+
+	return &out, nil
+}
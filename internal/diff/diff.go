@@ -0,0 +1,110 @@
+// Package diff compares two WilayahData snapshots and reports what changed
+// at each level of the hierarchy, the same way a config/target reloader
+// detects changed sets by comparing current vs previous snapshots.
+package diff
+
+import "wilayah-api/internal/scraper"
+
+// Entry identifies one node in the wilayah tree by its full ancestry, so an
+// added/removed/renamed kecamatan (say) can still be placed under its
+// province and kabupaten in the report.
+type Entry struct {
+	Pro  string `json:"pro"`
+	Kab  string `json:"kab,omitempty"`
+	Kec  string `json:"kec,omitempty"`
+	Des  string `json:"des,omitempty"`
+	Nama string `json:"nama"`
+}
+
+// Rename records an ID whose name changed between snapshots.
+type Rename struct {
+	Entry
+	OldNama string `json:"old_nama"`
+	NewNama string `json:"new_nama"`
+}
+
+// LevelDiff is the added/removed/renamed set for one tree level.
+type LevelDiff struct {
+	Added   []Entry  `json:"added,omitempty"`
+	Removed []Entry  `json:"removed,omitempty"`
+	Renamed []Rename `json:"renamed,omitempty"`
+}
+
+// Result is the full diff between two snapshots, one LevelDiff per level.
+type Result struct {
+	Provinsi  LevelDiff `json:"provinsi"`
+	Kabupaten LevelDiff `json:"kabupaten"`
+	Kecamatan LevelDiff `json:"kecamatan"`
+	Desa      LevelDiff `json:"desa"`
+}
+
+// node is the flattened (ancestry, id, nama) shape diffing operates on, so
+// the same compare logic works at every level.
+type node struct {
+	entry Entry
+	id    string
+	nama  string
+}
+
+// Compute returns the diff of new relative to old: nodes present in new but
+// not old are "added", nodes present in old but not new are "removed", and
+// nodes present in both with a changed Nama are "renamed".
+func Compute(oldData, newData *scraper.WilayahData) *Result {
+	oldPro, oldKab, oldKec, oldDes := flatten(oldData)
+	newPro, newKab, newKec, newDes := flatten(newData)
+
+	return &Result{
+		Provinsi:  compareLevel(oldPro, newPro),
+		Kabupaten: compareLevel(oldKab, newKab),
+		Kecamatan: compareLevel(oldKec, newKec),
+		Desa:      compareLevel(oldDes, newDes),
+	}
+}
+
+func flatten(data *scraper.WilayahData) (pro, kab, kec, des []node) {
+	if data == nil {
+		return
+	}
+	for _, p := range data.Pro {
+		pro = append(pro, node{entry: Entry{Pro: p.ID}, id: p.ID, nama: p.Nama})
+		for _, k := range p.Kab {
+			kab = append(kab, node{entry: Entry{Pro: p.ID, Kab: k.ID}, id: p.ID + "/" + k.ID, nama: k.Nama})
+			for _, kc := range k.Kec {
+				kec = append(kec, node{entry: Entry{Pro: p.ID, Kab: k.ID, Kec: kc.ID}, id: p.ID + "/" + k.ID + "/" + kc.ID, nama: kc.Nama})
+				for _, d := range kc.Des {
+					des = append(des, node{entry: Entry{Pro: p.ID, Kab: k.ID, Kec: kc.ID, Des: d.ID}, id: p.ID + "/" + k.ID + "/" + kc.ID + "/" + d.ID, nama: d.Nama})
+				}
+			}
+		}
+	}
+	return
+}
+
+func compareLevel(oldNodes, newNodes []node) LevelDiff {
+	oldByID := make(map[string]node, len(oldNodes))
+	for _, n := range oldNodes {
+		oldByID[n.id] = n
+	}
+	newByID := make(map[string]node, len(newNodes))
+	for _, n := range newNodes {
+		newByID[n.id] = n
+	}
+
+	var ld LevelDiff
+	for id, n := range newByID {
+		old, existed := oldByID[id]
+		if !existed {
+			ld.Added = append(ld.Added, n.entry)
+			continue
+		}
+		if old.nama != n.nama {
+			ld.Renamed = append(ld.Renamed, Rename{Entry: n.entry, OldNama: old.nama, NewNama: n.nama})
+		}
+	}
+	for id, n := range oldByID {
+		if _, stillExists := newByID[id]; !stillExists {
+			ld.Removed = append(ld.Removed, n.entry)
+		}
+	}
+	return ld
+}
@@ -0,0 +1,149 @@
+// Package exporter turns a scraped WilayahData tree into the output format a
+// downstream consumer actually wants — pretty JSON, NDJSON for streaming,
+// flattened CSV for spreadsheets, or a normalized SQLite database for joins.
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"wilayah-api/internal/scraper"
+)
+
+// Exporter writes a WilayahData tree to outputPath in its own format.
+type Exporter interface {
+	// Export writes data to outputPath, creating parent directories as
+	// needed.
+	Export(data *scraper.WilayahData, outputPath string) error
+}
+
+// New returns the Exporter for the given format name. Supported formats are
+// "json" (default), "ndjson", "csv", "sql", and "sqlite".
+func New(format string) (Exporter, error) {
+	switch format {
+	case "", "json":
+		return JSONExporter{}, nil
+	case "ndjson":
+		return NDJSONExporter{}, nil
+	case "csv":
+		return CSVExporter{}, nil
+	case "sql":
+		return SQLExporter{}, nil
+	case "sqlite":
+		return SQLiteExporter{}, nil
+	default:
+		return nil, fmt.Errorf("exporter: unknown format %q (want json, ndjson, csv, sql, or sqlite)", format)
+	}
+}
+
+func createFile(path string) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	return os.Create(path)
+}
+
+// flatRow is one desa/kelurahan with its full ancestry, the unit the CSV and
+// NDJSON exporters both flatten the tree into.
+type flatRow struct {
+	ProID, ProNama string
+	KabID, KabNama string
+	KecID, KecNama string
+	DesID, DesNama string
+}
+
+func flatten(data *scraper.WilayahData) []flatRow {
+	var rows []flatRow
+	for _, p := range data.Pro {
+		for _, k := range p.Kab {
+			for _, kc := range k.Kec {
+				for _, d := range kc.Des {
+					rows = append(rows, flatRow{
+						ProID: p.ID, ProNama: p.Nama,
+						KabID: k.ID, KabNama: k.Nama,
+						KecID: kc.ID, KecNama: kc.Nama,
+						DesID: d.ID, DesNama: d.Nama,
+					})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// JSONExporter writes the tree as one pretty-printed JSON document, the same
+// shape saveToFile has always produced.
+type JSONExporter struct{}
+
+func (JSONExporter) Export(data *scraper.WilayahData, outputPath string) error {
+	file, err := createFile(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(data)
+}
+
+// NDJSONExporter writes one flattened desa record per line, streamable
+// without loading the whole file into memory.
+type NDJSONExporter struct{}
+
+func (NDJSONExporter) Export(data *scraper.WilayahData, outputPath string) error {
+	file, err := createFile(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetEscapeHTML(false)
+	for _, row := range flatten(data) {
+		record := map[string]string{
+			"prov_id": row.ProID, "prov_nama": row.ProNama,
+			"kab_id": row.KabID, "kab_nama": row.KabNama,
+			"kec_id": row.KecID, "kec_nama": row.KecNama,
+			"desa_id": row.DesID, "desa_nama": row.DesNama,
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSVExporter writes one flattened row per desa/kelurahan:
+// prov_id,prov_nama,kab_id,kab_nama,kec_id,kec_nama,desa_id,desa_nama.
+type CSVExporter struct{}
+
+func (CSVExporter) Export(data *scraper.WilayahData, outputPath string) error {
+	file, err := createFile(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	defer w.Flush()
+
+	header := []string{"prov_id", "prov_nama", "kab_id", "kab_nama", "kec_id", "kec_nama", "desa_id", "desa_nama"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range flatten(data) {
+		record := []string{row.ProID, row.ProNama, row.KabID, row.KabNama, row.KecID, row.KecNama, row.DesID, row.DesNama}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return w.Error()
+}
@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"fmt"
+	"strings"
+
+	"wilayah-api/internal/scraper"
+)
+
+// SQLExporter writes a plain-text SQL dump (schema + INSERT statements for
+// provinsi/kabupaten/kecamatan/desa) that can be piped into any RDBMS, e.g.
+// `sqlite3 out.db < wilayah_final.sql` or `mysql db < wilayah_final.sql`.
+type SQLExporter struct{}
+
+func (SQLExporter) Export(data *scraper.WilayahData, outputPath string) error {
+	file, err := createFile(outputPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := strings.Builder{}
+	w.WriteString(`CREATE TABLE provinsi (id TEXT PRIMARY KEY, nama TEXT NOT NULL);` + "\n")
+	w.WriteString(`CREATE TABLE kabupaten (id TEXT NOT NULL, prov_id TEXT NOT NULL, nama TEXT NOT NULL, PRIMARY KEY (prov_id, id));` + "\n")
+	w.WriteString(`CREATE TABLE kecamatan (id TEXT NOT NULL, prov_id TEXT NOT NULL, kab_id TEXT NOT NULL, nama TEXT NOT NULL, PRIMARY KEY (prov_id, kab_id, id));` + "\n")
+	w.WriteString(`CREATE TABLE desa (id TEXT NOT NULL, prov_id TEXT NOT NULL, kab_id TEXT NOT NULL, kec_id TEXT NOT NULL, nama TEXT NOT NULL, PRIMARY KEY (prov_id, kab_id, kec_id, id));` + "\n\n")
+
+	for _, p := range data.Pro {
+		w.WriteString(insertStmt("provinsi", p.ID, p.Nama))
+		for _, k := range p.Kab {
+			w.WriteString(insertStmt("kabupaten", k.ID, p.ID, k.Nama))
+			for _, kc := range k.Kec {
+				w.WriteString(insertStmt("kecamatan", kc.ID, p.ID, k.ID, kc.Nama))
+				for _, d := range kc.Des {
+					w.WriteString(insertStmt("desa", d.ID, p.ID, k.ID, kc.ID, d.Nama))
+				}
+			}
+		}
+	}
+
+	_, err = file.WriteString(w.String())
+	return err
+}
+
+// insertStmt builds a single-row INSERT INTO table VALUES (...) statement,
+// quoting and escaping every value as a SQL string literal.
+func insertStmt(table string, values ...string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return fmt.Sprintf("INSERT INTO %s VALUES (%s);\n", table, strings.Join(quoted, ", "))
+}
@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"wilayah-api/internal/scraper"
+)
+
+// SQLiteExporter writes four normalized tables (provinsi, kabupaten,
+// kecamatan, desa) with foreign keys to their parent and indexes on id and
+// nama, so downstream consumers can join instead of re-flattening JSON.
+type SQLiteExporter struct{}
+
+func (SQLiteExporter) Export(data *scraper.WilayahData, outputPath string) error {
+	// SQLite opens (and creates) the file itself; just make sure the parent
+	// directory exists and start from a clean file like the other exporters.
+	os.Remove(outputPath)
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite3", outputPath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := createSchema(db); err != nil {
+		return fmt.Errorf("exporter: creating schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := writeRows(tx, data); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func createSchema(db *sql.DB) error {
+	stmts := []string{
+		`CREATE TABLE provinsi (
+			id   TEXT PRIMARY KEY,
+			nama TEXT NOT NULL
+		)`,
+		`CREATE TABLE kabupaten (
+			id        TEXT NOT NULL,
+			prov_id   TEXT NOT NULL REFERENCES provinsi(id),
+			nama      TEXT NOT NULL,
+			PRIMARY KEY (prov_id, id)
+		)`,
+		`CREATE TABLE kecamatan (
+			id        TEXT NOT NULL,
+			prov_id   TEXT NOT NULL,
+			kab_id    TEXT NOT NULL,
+			nama      TEXT NOT NULL,
+			PRIMARY KEY (prov_id, kab_id, id),
+			FOREIGN KEY (prov_id, kab_id) REFERENCES kabupaten(prov_id, id)
+		)`,
+		`CREATE TABLE desa (
+			id        TEXT NOT NULL,
+			prov_id   TEXT NOT NULL,
+			kab_id    TEXT NOT NULL,
+			kec_id    TEXT NOT NULL,
+			nama      TEXT NOT NULL,
+			PRIMARY KEY (prov_id, kab_id, kec_id, id),
+			FOREIGN KEY (prov_id, kab_id, kec_id) REFERENCES kecamatan(prov_id, kab_id, id)
+		)`,
+		`CREATE INDEX idx_provinsi_nama ON provinsi(nama)`,
+		`CREATE INDEX idx_kabupaten_nama ON kabupaten(nama)`,
+		`CREATE INDEX idx_kecamatan_nama ON kecamatan(nama)`,
+		`CREATE INDEX idx_desa_nama ON desa(nama)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRows(tx *sql.Tx, data *scraper.WilayahData) error {
+	insertPro, err := tx.Prepare(`INSERT INTO provinsi (id, nama) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insertPro.Close()
+
+	insertKab, err := tx.Prepare(`INSERT INTO kabupaten (id, prov_id, nama) VALUES (?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insertKab.Close()
+
+	insertKec, err := tx.Prepare(`INSERT INTO kecamatan (id, prov_id, kab_id, nama) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insertKec.Close()
+
+	insertDes, err := tx.Prepare(`INSERT INTO desa (id, prov_id, kab_id, kec_id, nama) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer insertDes.Close()
+
+	for _, p := range data.Pro {
+		if _, err := insertPro.Exec(p.ID, p.Nama); err != nil {
+			return err
+		}
+		for _, k := range p.Kab {
+			if _, err := insertKab.Exec(k.ID, p.ID, k.Nama); err != nil {
+				return err
+			}
+			for _, kc := range k.Kec {
+				if _, err := insertKec.Exec(kc.ID, p.ID, k.ID, kc.Nama); err != nil {
+					return err
+				}
+				for _, d := range kc.Des {
+					if _, err := insertDes.Exec(d.ID, p.ID, k.ID, kc.ID, d.Nama); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package fetcher
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ResponseCache is a response-body cache keyed by (endpoint, params), so a
+// scraper resuming from a checkpoint doesn't re-hit the upstream API for
+// subtrees it already fetched in a prior run. It is safe for concurrent use.
+type ResponseCache struct {
+	mu      sync.RWMutex
+	path    string
+	entries map[string]json.RawMessage
+	dirty   bool
+}
+
+// NewResponseCache creates a cache backed by path, loading any entries
+// already written there. A missing file just starts empty.
+func NewResponseCache(path string) (*ResponseCache, error) {
+	c := &ResponseCache{path: path, entries: make(map[string]json.RawMessage)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		// A truncated/corrupt cache file is not fatal: start fresh rather
+		// than blocking the scrape.
+		c.entries = make(map[string]json.RawMessage)
+	}
+	return c, nil
+}
+
+// CacheKey builds a stable key from an endpoint and its query params.
+func CacheKey(endpoint string, params map[string]interface{}) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha1.New()
+	fmt.Fprint(h, endpoint)
+	for _, k := range keys {
+		fmt.Fprintf(h, "|%s=%v", k, params[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached body for key, if present.
+func (c *ResponseCache) Get(key string) (json.RawMessage, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+// Set stores body under key.
+func (c *ResponseCache) Set(key string, body json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = body
+	c.dirty = true
+}
+
+// Save persists the cache to disk via tempfile+rename, mirroring the
+// scraper's atomic checkpoint writes. It is a no-op if nothing changed since
+// the last Save.
+func (c *ResponseCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if err := json.NewEncoder(tmp).Encode(c.entries); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpName, c.path); err != nil {
+		return err
+	}
+
+	c.dirty = false
+	return nil
+}
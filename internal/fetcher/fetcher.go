@@ -0,0 +1,283 @@
+// Package fetcher provides a polite HTTP client for hammering a single
+// upstream API from many concurrent workers: a shared token-bucket rate
+// limiter, exponential backoff with jitter on retryable errors, Retry-After
+// honoring, and a small per-endpoint circuit breaker.
+package fetcher
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Config controls the politeness knobs exposed to callers (and, in turn, to
+// CLI flags).
+type Config struct {
+	RPS             float64       // requests per second across all workers (0 = unlimited)
+	MaxRetries      int           // retry attempts after the first try
+	BackoffBase     time.Duration // base delay for exponential backoff
+	Timeout         time.Duration // per-request timeout
+	BreakerFailures int           // consecutive failures before an endpoint trips open
+	BreakerCooldown time.Duration // how long a tripped endpoint stays open
+	OnRetry         func()        // called once per retry attempt, e.g. to feed a metrics counter
+}
+
+// DefaultConfig returns the scraper's default politeness settings (5
+// requests/sec, 3 retries from a 500ms backoff base, a 10s timeout, and a
+// breaker that trips after 5 failures for 30s) so callers can override only
+// what they care about.
+func DefaultConfig() Config {
+	return Config{
+		RPS:             5,
+		MaxRetries:      3,
+		BackoffBase:     500 * time.Millisecond,
+		Timeout:         10 * time.Second,
+		BreakerFailures: 5,
+		BreakerCooldown: 30 * time.Second,
+	}
+}
+
+// HTTPFetcher is the interface callers depend on instead of *Client
+// directly, so tests (or alternative transports) can swap in a fake without
+// touching scraper code.
+type HTTPFetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client wraps *http.Client with rate limiting, retries, and circuit
+// breaking. It is safe for concurrent use by multiple goroutines, and
+// implements HTTPFetcher.
+type Client struct {
+	httpClient *http.Client
+	cfg        Config
+	limiter    *tokenBucket
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// NewClient creates a Client ready to use. A zero-value Config falls back to
+// DefaultConfig's retry/backoff/breaker settings but leaves rate limiting
+// unbounded.
+func NewClient(cfg Config) *Client {
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultConfig().MaxRetries
+	}
+	if cfg.BackoffBase == 0 {
+		cfg.BackoffBase = DefaultConfig().BackoffBase
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultConfig().Timeout
+	}
+	if cfg.BreakerFailures == 0 {
+		cfg.BreakerFailures = DefaultConfig().BreakerFailures
+	}
+	if cfg.BreakerCooldown == 0 {
+		cfg.BreakerCooldown = DefaultConfig().BreakerCooldown
+	}
+
+	var limiter *tokenBucket
+	if cfg.RPS > 0 {
+		limiter = newTokenBucket(cfg.RPS)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		cfg:        cfg,
+		limiter:    limiter,
+		breakers:   make(map[string]*circuitBreaker),
+	}
+}
+
+// Do executes req, retrying on network errors, 429s, and 5xx responses with
+// exponential backoff and jitter. It honors a Retry-After header when
+// present. Each distinct req.URL.Path gets its own circuit breaker so one
+// consistently failing endpoint doesn't keep consuming rate-limit budget for
+// the rest.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	breaker := c.breakerFor(req.URL.Path)
+	if !breaker.allow() {
+		return nil, fmt.Errorf("fetcher: circuit breaker open for %s", req.URL.Path)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.cfg.MaxRetries; attempt++ {
+		if c.limiter != nil {
+			c.limiter.wait()
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			breaker.recordSuccess()
+			return resp, nil
+		}
+
+		var retryAfter time.Duration
+		if err == nil {
+			retryAfter = retryAfterDuration(resp)
+			lastErr = fmt.Errorf("fetcher: unexpected status %d from %s", resp.StatusCode, req.URL.String())
+			resp.Body.Close()
+		} else {
+			lastErr = err
+		}
+
+		breaker.recordFailure()
+		if attempt == c.cfg.MaxRetries {
+			break
+		}
+
+		if c.cfg.OnRetry != nil {
+			c.cfg.OnRetry()
+		}
+
+		delay := retryAfter
+		if delay == 0 {
+			delay = backoffWithJitter(c.cfg.BackoffBase, attempt)
+		}
+		time.Sleep(delay)
+	}
+
+	return nil, fmt.Errorf("fetcher: giving up after %d attempts: %w", c.cfg.MaxRetries+1, lastErr)
+}
+
+func (c *Client) breakerFor(endpoint string) *circuitBreaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[endpoint]
+	if !ok {
+		b = newCircuitBreaker(c.cfg.BreakerFailures, c.cfg.BreakerCooldown)
+		c.breakers[endpoint] = b
+	}
+	return b
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfterDuration parses the Retry-After header, which may be either a
+// number of seconds or an HTTP date. A zero duration means "not present".
+func retryAfterDuration(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// backoffWithJitter returns base * 2^attempt plus up to base of random
+// jitter, so concurrent workers retrying the same failure don't all line up
+// on the same wall-clock tick.
+func backoffWithJitter(base time.Duration, attempt int) time.Duration {
+	backoff := base << attempt
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return backoff + jitter
+}
+
+// tokenBucket is a minimal shared rate limiter: one token is added every
+// 1/rps seconds, up to a small burst, and wait() blocks until a token is
+// available.
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	max      float64
+	rate     float64 // tokens per second
+	lastFill time.Time
+}
+
+func newTokenBucket(rps float64) *tokenBucket {
+	return &tokenBucket{
+		tokens:   rps,
+		max:      rps,
+		rate:     rps,
+		lastFill: time.Now(),
+	}
+}
+
+func (t *tokenBucket) wait() {
+	for {
+		t.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(t.lastFill).Seconds()
+		t.tokens += elapsed * t.rate
+		if t.tokens > t.max {
+			t.tokens = t.max
+		}
+		t.lastFill = now
+
+		if t.tokens >= 1 {
+			t.tokens--
+			t.mu.Unlock()
+			return
+		}
+		missing := 1 - t.tokens
+		sleep := time.Duration(missing/t.rate*1000) * time.Millisecond
+		t.mu.Unlock()
+		if sleep <= 0 {
+			sleep = time.Millisecond
+		}
+		time.Sleep(sleep)
+	}
+}
+
+// circuitBreaker trips open after consecutive failures and refuses requests
+// until the cooldown elapses. The first allow() after the cooldown closes it
+// again and resets the failure count; it does not limit how many requests
+// pass from then on, and only reopens after another maxFailures failures.
+type circuitBreaker struct {
+	mu           sync.Mutex
+	maxFailures  int
+	cooldown     time.Duration
+	failureCount int
+	openedAt     time.Time
+	open         bool
+}
+
+func newCircuitBreaker(maxFailures int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{maxFailures: maxFailures, cooldown: cooldown}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	if time.Since(b.openedAt) >= b.cooldown {
+		// Cooldown elapsed: close the breaker and count failures afresh.
+		b.open = false
+		b.failureCount = 0
+		return true
+	}
+	return false
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failureCount = 0
+	b.open = false
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failureCount++
+	if b.failureCount >= b.maxFailures {
+		b.open = true
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,25 @@
+// Package logging provides the small Logger seam the scraper logs its
+// operational messages through (signal handling, checkpoint saves, resumed
+// runs). Replacing the old emoji fmt.Printf calls with this lets an
+// overnight run ship structured, greppable records instead of text meant
+// for a human watching the terminal.
+package logging
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Logger is the interface scraper code logs through. *slog.Logger already
+// satisfies it, so the default constructors below need no adapter.
+type Logger interface {
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// NewJSON returns a Logger that writes structured JSON records to w. This is
+// the default used when a caller doesn't configure one.
+func NewJSON(w io.Writer) Logger {
+	return slog.New(slog.NewJSONHandler(w, nil))
+}
@@ -0,0 +1,149 @@
+// Package metrics exposes Prometheus counters and gauges for long-running
+// scrape jobs, replacing stdout log spam with something that can actually be
+// alerted on.
+package metrics
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// RequestsTotal counts every upstream call by endpoint and outcome.
+	RequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wilayah_scrape_requests_total",
+			Help: "Total upstream HTTP requests made by the scraper.",
+		},
+		[]string{"endpoint", "status"},
+	)
+
+	// RequestDuration tracks upstream call latency by endpoint.
+	RequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "wilayah_scrape_request_duration_seconds",
+			Help:    "Upstream HTTP request latency in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"endpoint"},
+	)
+
+	// RetriesTotal counts every HTTP retry attempt made by the fetcher,
+	// regardless of which endpoint triggered it.
+	RetriesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "wilayah_scrape_retries_total",
+		Help: "Total HTTP retry attempts made by the scraper's fetcher.",
+	})
+
+	// ScrapeErrorsTotal counts failures encountered while descending the
+	// provinsi/kabupaten/kecamatan/desa tree.
+	ScrapeErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wilayah_scrape_errors_total",
+			Help: "Total scrape errors by tree level.",
+		},
+		[]string{"level"},
+	)
+
+	// ItemsTotal counts provinsi/kabupaten/kecamatan/desa entries completed
+	// in the current process, by tree level.
+	ItemsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wilayah_scrape_items_total",
+			Help: "Total provinsi/kabupaten/kecamatan/desa entries scraped, by level.",
+		},
+		[]string{"level"},
+	)
+
+	// ProvinsiProcessed is the count of provinces completed in the current run.
+	ProvinsiProcessed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "wilayah_provinsi_processed",
+		Help: "Number of provinces fully processed in the current scrape run.",
+	})
+
+	// WorkersActive is the number of kabupaten workers currently fetching.
+	WorkersActive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "wilayah_scrape_workers_active",
+		Help: "Number of kabupaten workers currently processing.",
+	})
+
+	// CheckpointLastSave is the unix timestamp of the last successful
+	// checkpoint write.
+	CheckpointLastSave = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "wilayah_checkpoint_last_save_timestamp",
+		Help: "Unix timestamp of the last successful checkpoint save.",
+	})
+
+	// CheckpointSavesTotal counts every successful checkpoint write.
+	CheckpointSavesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "wilayah_scrape_checkpoint_saves_total",
+		Help: "Total number of successful checkpoint saves.",
+	})
+
+	// APIDeadlineExceededTotal counts requests to ctx-aware HTTP API
+	// endpoints (search, desa) that hit their deadline before finishing and
+	// returned a partial result instead of the full one.
+	APIDeadlineExceededTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "wilayah_api_deadline_exceeded_total",
+			Help: "Total API requests that hit their deadline and returned a partial result, by endpoint.",
+		},
+		[]string{"endpoint"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		RequestsTotal,
+		RequestDuration,
+		RetriesTotal,
+		ScrapeErrorsTotal,
+		ItemsTotal,
+		ProvinsiProcessed,
+		WorkersActive,
+		CheckpointLastSave,
+		CheckpointSavesTotal,
+		APIDeadlineExceededTotal,
+	)
+}
+
+// ObserveRequest records the outcome and latency of a single upstream call.
+func ObserveRequest(endpoint, status string, duration time.Duration) {
+	RequestsTotal.WithLabelValues(endpoint, status).Inc()
+	RequestDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+// RecordRetry marks a single HTTP retry attempt.
+func RecordRetry() {
+	RetriesTotal.Inc()
+}
+
+// RecordCheckpointSave marks a checkpoint as just having been saved.
+func RecordCheckpointSave() {
+	CheckpointLastSave.Set(float64(time.Now().Unix()))
+	CheckpointSavesTotal.Inc()
+}
+
+// RecordAPIDeadlineExceeded marks a single HTTP API request that ran out of
+// its per-request deadline and returned a partial result for endpoint.
+func RecordAPIDeadlineExceeded(endpoint string) {
+	APIDeadlineExceededTotal.WithLabelValues(endpoint).Inc()
+}
+
+// Serve starts the /metrics HTTP server on addr in the background. It is
+// opt-in: callers only invoke it when --metrics-addr is set.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		log.Printf("Metrics server listening on %s/metrics", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Metrics server error: %v", err)
+		}
+	}()
+}
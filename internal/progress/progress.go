@@ -0,0 +1,145 @@
+// Package progress renders live scrape progress to the terminal and exposes
+// the same counters GetProgress() returns over the API, so the CLI bar and
+// the HTTP endpoint never disagree about how far along a run is.
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Mode controls how a Reporter renders.
+type Mode int
+
+const (
+	// Plain prints one line per event, the scraper's original behavior.
+	Plain Mode = iota
+	// Bar renders a single live, overwritten status line via a ticker.
+	Bar
+	// Silent tracks counters but prints nothing.
+	Silent
+)
+
+// ParseMode maps the --progress/--no-progress/--silent flags to a Mode.
+func ParseMode(progress, noProgress, silent bool) Mode {
+	switch {
+	case silent:
+		return Silent
+	case progress:
+		return Bar
+	case noProgress:
+		return Plain
+	default:
+		return Plain
+	}
+}
+
+// Reporter tracks scrape progress and, in Bar mode, renders it as a live
+// single-line terminal bar. All counters are safe for concurrent use by many
+// worker goroutines.
+type Reporter struct {
+	mode Mode
+
+	mu          sync.Mutex // guards stdout so bar renders and log lines never interleave
+	stop        chan struct{}
+	wg          sync.WaitGroup
+	lastLineLen int
+
+	totalProvinsi int64
+	doneProvinsi  int64
+	kabInFlight   int64
+	kecDone       int64
+	desaDone      int64
+}
+
+// NewReporter creates a Reporter in the given mode.
+func NewReporter(mode Mode) *Reporter {
+	return &Reporter{mode: mode, stop: make(chan struct{})}
+}
+
+func (r *Reporter) SetTotalProvinsi(n int) { atomic.StoreInt64(&r.totalProvinsi, int64(n)) }
+func (r *Reporter) ProvinsiDone()          { atomic.AddInt64(&r.doneProvinsi, 1) }
+func (r *Reporter) KabupatenStarted()      { atomic.AddInt64(&r.kabInFlight, 1) }
+func (r *Reporter) KabupatenFinished()     { atomic.AddInt64(&r.kabInFlight, -1) }
+func (r *Reporter) KecamatanDone()         { atomic.AddInt64(&r.kecDone, 1) }
+func (r *Reporter) DesaAdded(n int)        { atomic.AddInt64(&r.desaDone, int64(n)) }
+
+// Snapshot returns the current counters in the same shape GetProgress()
+// returns to API callers.
+func (r *Reporter) Snapshot() map[string]interface{} {
+	return map[string]interface{}{
+		"provinsi_total":      atomic.LoadInt64(&r.totalProvinsi),
+		"provinsi_done":       atomic.LoadInt64(&r.doneProvinsi),
+		"kabupaten_in_flight": atomic.LoadInt64(&r.kabInFlight),
+		"kecamatan_done":      atomic.LoadInt64(&r.kecDone),
+		"desa_done":           atomic.LoadInt64(&r.desaDone),
+	}
+}
+
+// Log prints a worker log line. In Bar mode it pauses the live bar first so
+// the two never corrupt each other; in Silent mode it's suppressed.
+func (r *Reporter) Log(format string, args ...interface{}) {
+	if r.mode == Silent {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == Bar {
+		r.clearLineLocked()
+	}
+	fmt.Printf(format+"\n", args...)
+}
+
+// Start begins the render ticker. It is a no-op outside Bar mode. Call Stop
+// when the scrape finishes.
+func (r *Reporter) Start() {
+	if r.mode != Bar {
+		return
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(300 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.render()
+			case <-r.stop:
+				r.render()
+				fmt.Println()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the render ticker and waits for it to finish its last frame.
+func (r *Reporter) Stop() {
+	if r.mode != Bar {
+		return
+	}
+	close(r.stop)
+	r.wg.Wait()
+}
+
+func (r *Reporter) render() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	line := fmt.Sprintf("📊 Provinsi %d/%d | Kabupaten aktif: %d | Kecamatan selesai: %d | Desa selesai: %d",
+		atomic.LoadInt64(&r.doneProvinsi), atomic.LoadInt64(&r.totalProvinsi),
+		atomic.LoadInt64(&r.kabInFlight), atomic.LoadInt64(&r.kecDone), atomic.LoadInt64(&r.desaDone))
+	r.clearLineLocked()
+	fmt.Print(line)
+	r.lastLineLen = len(line)
+}
+
+func (r *Reporter) clearLineLocked() {
+	if r.lastLineLen > 0 {
+		fmt.Printf("\r%s\r", strings.Repeat(" ", r.lastLineLen))
+		r.lastLineLen = 0
+	}
+}
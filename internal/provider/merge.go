@@ -0,0 +1,86 @@
+package provider
+
+import "context"
+
+// MergeProvider reconciles two providers by id: every id from Primary or
+// Secondary ends up in the result, Primary's nama wins on conflict, and
+// OnMismatch (if set) is called for every id present in both sets whose nama
+// disagrees, so callers can cross-validate two sources (e.g. sipedas vs. a
+// BPS snapshot) and surface discrepancies instead of silently picking one.
+type MergeProvider struct {
+	Primary    Provider
+	Secondary  Provider
+	OnMismatch func(level, id, primaryNama, secondaryNama string)
+}
+
+// NewMergeProvider creates a MergeProvider. onMismatch may be nil to ignore
+// discrepancies.
+func NewMergeProvider(primary, secondary Provider, onMismatch func(level, id, primaryNama, secondaryNama string)) *MergeProvider {
+	return &MergeProvider{Primary: primary, Secondary: secondary, OnMismatch: onMismatch}
+}
+
+func (p *MergeProvider) ListProvinsi(ctx context.Context, year int) (map[string]string, error) {
+	a, err := p.Primary.ListProvinsi(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.Secondary.ListProvinsi(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	return p.merge("provinsi", a, b), nil
+}
+
+func (p *MergeProvider) ListKabupaten(ctx context.Context, year int, provID string) (map[string]string, error) {
+	a, err := p.Primary.ListKabupaten(ctx, year, provID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.Secondary.ListKabupaten(ctx, year, provID)
+	if err != nil {
+		return nil, err
+	}
+	return p.merge("kabupaten", a, b), nil
+}
+
+func (p *MergeProvider) ListKecamatan(ctx context.Context, year int, provID, kabID string) (map[string]string, error) {
+	a, err := p.Primary.ListKecamatan(ctx, year, provID, kabID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.Secondary.ListKecamatan(ctx, year, provID, kabID)
+	if err != nil {
+		return nil, err
+	}
+	return p.merge("kecamatan", a, b), nil
+}
+
+func (p *MergeProvider) ListDesa(ctx context.Context, year int, provID, kabID, kecID string) (map[string]string, error) {
+	a, err := p.Primary.ListDesa(ctx, year, provID, kabID, kecID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := p.Secondary.ListDesa(ctx, year, provID, kabID, kecID)
+	if err != nil {
+		return nil, err
+	}
+	return p.merge("desa", a, b), nil
+}
+
+func (p *MergeProvider) merge(level string, primary, secondary map[string]string) map[string]string {
+	merged := make(map[string]string, len(primary)+len(secondary))
+	for id, nama := range primary {
+		merged[id] = nama
+	}
+	for id, nama := range secondary {
+		existing, ok := merged[id]
+		if !ok {
+			merged[id] = nama
+			continue
+		}
+		if existing != nama && p.OnMismatch != nil {
+			p.OnMismatch(level, id, existing, nama)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,16 @@
+// Package provider abstracts where the wilayah tree data comes from, so the
+// same Scraper pipeline can walk the live sipedas API, a local snapshot file,
+// or both at once reconciled against each other.
+package provider
+
+import "context"
+
+// Provider lists one level of the wilayah tree at a time, mirroring the
+// provinsi -> kabupaten -> kecamatan -> desa shape the scraper walks. Every
+// method returns a map of id -> nama for the requested level.
+type Provider interface {
+	ListProvinsi(ctx context.Context, year int) (map[string]string, error)
+	ListKabupaten(ctx context.Context, year int, provID string) (map[string]string, error)
+	ListKecamatan(ctx context.Context, year int, provID, kabID string) (map[string]string, error)
+	ListDesa(ctx context.Context, year int, provID, kabID, kecID string) (map[string]string, error)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// SeedProvider serves a previously scraped wilayah_final_*.json snapshot,
+// letting the scraper pipeline run entirely offline against a fixed dataset
+// instead of hitting sipedas. It ignores the year argument on every method,
+// since a snapshot file only ever holds the one year it was scraped for.
+type SeedProvider struct {
+	data seedData
+}
+
+type seedDesa struct {
+	ID   string `json:"id"`
+	Nama string `json:"nama"`
+}
+
+type seedKec struct {
+	ID   string     `json:"id"`
+	Nama string     `json:"nama"`
+	Des  []seedDesa `json:"des"`
+}
+
+type seedKab struct {
+	ID   string    `json:"id"`
+	Nama string    `json:"nama"`
+	Kec  []seedKec `json:"kec"`
+}
+
+type seedPro struct {
+	ID   string    `json:"id"`
+	Nama string    `json:"nama"`
+	Kab  []seedKab `json:"kab"`
+}
+
+type seedData struct {
+	Pro []seedPro `json:"pro"`
+}
+
+// NewSeedProvider loads path (a wilayah_final_*.json file) into memory.
+func NewSeedProvider(path string) (*SeedProvider, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("provider: reading seed file: %w", err)
+	}
+
+	var data seedData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("provider: parsing seed file: %w", err)
+	}
+
+	return &SeedProvider{data: data}, nil
+}
+
+func (p *SeedProvider) ListProvinsi(ctx context.Context, year int) (map[string]string, error) {
+	result := make(map[string]string, len(p.data.Pro))
+	for _, pro := range p.data.Pro {
+		result[pro.ID] = pro.Nama
+	}
+	return result, nil
+}
+
+func (p *SeedProvider) ListKabupaten(ctx context.Context, year int, provID string) (map[string]string, error) {
+	pro, ok := p.findProvinsi(provID)
+	if !ok {
+		return nil, fmt.Errorf("provider: provinsi %q not found in seed", provID)
+	}
+	result := make(map[string]string, len(pro.Kab))
+	for _, kab := range pro.Kab {
+		result[kab.ID] = kab.Nama
+	}
+	return result, nil
+}
+
+func (p *SeedProvider) ListKecamatan(ctx context.Context, year int, provID, kabID string) (map[string]string, error) {
+	kab, ok := p.findKabupaten(provID, kabID)
+	if !ok {
+		return nil, fmt.Errorf("provider: kabupaten %q not found in seed", kabID)
+	}
+	result := make(map[string]string, len(kab.Kec))
+	for _, kec := range kab.Kec {
+		result[kec.ID] = kec.Nama
+	}
+	return result, nil
+}
+
+func (p *SeedProvider) ListDesa(ctx context.Context, year int, provID, kabID, kecID string) (map[string]string, error) {
+	kab, ok := p.findKabupaten(provID, kabID)
+	if !ok {
+		return nil, fmt.Errorf("provider: kabupaten %q not found in seed", kabID)
+	}
+	for _, kec := range kab.Kec {
+		if kec.ID != kecID {
+			continue
+		}
+		result := make(map[string]string, len(kec.Des))
+		for _, des := range kec.Des {
+			result[des.ID] = des.Nama
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("provider: kecamatan %q not found in seed", kecID)
+}
+
+func (p *SeedProvider) findProvinsi(provID string) (seedPro, bool) {
+	for _, pro := range p.data.Pro {
+		if pro.ID == provID {
+			return pro, true
+		}
+	}
+	return seedPro{}, false
+}
+
+func (p *SeedProvider) findKabupaten(provID, kabID string) (seedKab, bool) {
+	pro, ok := p.findProvinsi(provID)
+	if !ok {
+		return seedKab{}, false
+	}
+	for _, kab := range pro.Kab {
+		if kab.ID == kabID {
+			return kab, true
+		}
+	}
+	return seedKab{}, false
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"wilayah-api/internal/fetcher"
+	"wilayah-api/internal/metrics"
+)
+
+// SipedasProvider fetches list_pro/list_kab/list_kec/list_des from the
+// sipedas.pertanian.go.id wilayah API, the scraper's original and still
+// default source.
+type SipedasProvider struct {
+	baseURL string
+	client  fetcher.HTTPFetcher
+
+	mu    sync.RWMutex
+	cache *fetcher.ResponseCache
+}
+
+// NewSipedasProvider creates a provider that fetches against baseURL using
+// client. A response cache can be attached later with SetCache once the
+// caller knows where to persist it.
+func NewSipedasProvider(baseURL string, client fetcher.HTTPFetcher) *SipedasProvider {
+	return &SipedasProvider{baseURL: baseURL, client: client}
+}
+
+// SetCache attaches a response cache so repeated endpoint/param combinations
+// (e.g. resuming a checkpointed run) don't re-hit the upstream API.
+func (p *SipedasProvider) SetCache(cache *fetcher.ResponseCache) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache = cache
+}
+
+func (p *SipedasProvider) ListProvinsi(ctx context.Context, year int) (map[string]string, error) {
+	return p.fetch(ctx, "list_pro", map[string]interface{}{"thn": year})
+}
+
+func (p *SipedasProvider) ListKabupaten(ctx context.Context, year int, provID string) (map[string]string, error) {
+	return p.fetch(ctx, "list_kab", map[string]interface{}{"thn": year, "pro": provID})
+}
+
+func (p *SipedasProvider) ListKecamatan(ctx context.Context, year int, provID, kabID string) (map[string]string, error) {
+	return p.fetch(ctx, "list_kec", map[string]interface{}{"thn": year, "pro": provID, "kab": kabID})
+}
+
+func (p *SipedasProvider) ListDesa(ctx context.Context, year int, provID, kabID, kecID string) (map[string]string, error) {
+	return p.fetch(ctx, "list_des", map[string]interface{}{"thn": year, "pro": provID, "kab": kabID, "kec": kecID})
+}
+
+func (p *SipedasProvider) fetch(ctx context.Context, endpoint string, params map[string]interface{}) (map[string]string, error) {
+	p.mu.RLock()
+	cache := p.cache
+	p.mu.RUnlock()
+
+	var cacheKey string
+	if cache != nil {
+		cacheKey = fetcher.CacheKey(endpoint, params)
+		if body, ok := cache.Get(cacheKey); ok {
+			var result map[string]string
+			if err := json.Unmarshal(body, &result); err == nil {
+				return normalizeData(result), nil
+			}
+			// Fall through and re-fetch if the cached entry is corrupt.
+		}
+	}
+
+	start := time.Now()
+	status := "error"
+	defer func() {
+		metrics.ObserveRequest(endpoint, status, time.Since(start))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", p.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	for key, value := range params {
+		q.Add(key, fmt.Sprintf("%v", value))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	status = strconv.Itoa(resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		cache.Set(cacheKey, body)
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return normalizeData(result), nil
+}
+
+func normalizeText(text string) string {
+	replacements := map[string]string{
+		"\\'":     "'",
+		"\\\"":    "\"",
+		"\\\\":    "\\",
+		"\\/":     "/",
+		"\\u0027": "'",
+		"\\u0022": "\"",
+	}
+
+	for old, new := range replacements {
+		text = strings.ReplaceAll(text, old, new)
+	}
+
+	return text
+}
+
+func normalizeData(data map[string]string) map[string]string {
+	result := make(map[string]string, len(data))
+	for key, value := range data {
+		result[key] = normalizeText(value)
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+// Package query parses the filter/sort/pagination vocabulary shared by the
+// /provinsi, /kabupaten, /kecamatan, and /desa collection endpoints
+// (nama_like, nama_prefix, id_in, has_children_gte/lte, sort, order,
+// page/per_page), so a handler only has to call Parse then Apply instead of
+// repeating query-string parsing and an unknown-key check on every route.
+package query
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Filter is one parsed collection-listing request.
+type Filter struct {
+	NamaLike       string
+	NamaPrefix     string
+	IDIn           []string
+	HasChildrenGte *int
+	HasChildrenLte *int
+	Sort           string // "nama" | "id" | "children_count"
+	Order          string // "asc" | "desc"
+	Page           int
+	PerPage        int
+}
+
+// Problem is a structured 400 body for a filter Parse couldn't accept, e.g.
+// an unrecognized key -- a minimal RFC 7807 "problem details" shape.
+type Problem struct {
+	Error string   `json:"error"`
+	Keys  []string `json:"keys,omitempty"`
+}
+
+// Meta is the pagination summary Apply returns alongside its filtered page.
+type Meta struct {
+	Total   int `json:"total" example:"514"`
+	Page    int `json:"page" example:"1"`
+	PerPage int `json:"per_page" example:"25"`
+}
+
+// knownKeys is the filter vocabulary Parse recognizes. Any other key in
+// params, besides the caller's own allowedExtra (an endpoint's scoping
+// params, e.g. "pro"), is rejected with a Problem.
+var knownKeys = map[string]bool{
+	"nama_like": true, "nama_prefix": true, "id_in": true,
+	"has_children_gte": true, "has_children_lte": true,
+	"sort": true, "order": true, "page": true, "per_page": true,
+}
+
+// Parse reads a Filter out of params (a flat query-string key/value map).
+// active reports whether any key in knownKeys was actually supplied, so a
+// handler can fall back to its pre-filter plain-array response when the
+// request used none of this vocabulary. A key that's neither in knownKeys
+// nor allowedExtra fails the parse with a Problem listing every offending
+// key, sorted.
+func Parse(params map[string]string, allowedExtra ...string) (f Filter, active bool, problem *Problem) {
+	extra := make(map[string]bool, len(allowedExtra))
+	for _, k := range allowedExtra {
+		extra[k] = true
+	}
+
+	var unknown []string
+	for k := range params {
+		if knownKeys[k] {
+			active = true
+			continue
+		}
+		if !extra[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return Filter{}, false, &Problem{Error: "unknown filter key(s)", Keys: unknown}
+	}
+
+	f.NamaLike = strings.ToLower(strings.TrimSpace(params["nama_like"]))
+	f.NamaPrefix = strings.ToLower(strings.TrimSpace(params["nama_prefix"]))
+	if raw := strings.TrimSpace(params["id_in"]); raw != "" {
+		for _, id := range strings.Split(raw, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				f.IDIn = append(f.IDIn, id)
+			}
+		}
+	}
+	if raw := strings.TrimSpace(params["has_children_gte"]); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			f.HasChildrenGte = &n
+		}
+	}
+	if raw := strings.TrimSpace(params["has_children_lte"]); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil {
+			f.HasChildrenLte = &n
+		}
+	}
+	f.Sort = strings.ToLower(strings.TrimSpace(params["sort"]))
+	f.Order = "asc"
+	if strings.ToLower(strings.TrimSpace(params["order"])) == "desc" {
+		f.Order = "desc"
+	}
+	f.Page, _ = strconv.Atoi(params["page"])
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	f.PerPage, _ = strconv.Atoi(params["per_page"])
+	if f.PerPage <= 0 || f.PerPage > 200 {
+		f.PerPage = 25
+	}
+	return f, active, nil
+}
+
+// Item is the minimal shape Apply filters/sorts/paginates: handlers map
+// their own Response structs into Items, call Apply, then map the returned
+// page back.
+type Item struct {
+	ID            string
+	Nama          string
+	ChildrenCount int
+}
+
+// Apply filters items by f's predicates, sorts by f.Sort/f.Order (default:
+// by ID ascending), then slices out page f.Page/f.PerPage. Meta.Total is the
+// filtered count before paging.
+func Apply(items []Item, f Filter) (page []Item, meta Meta) {
+	filtered := make([]Item, 0, len(items))
+	for _, it := range items {
+		if f.NamaLike != "" && !strings.Contains(strings.ToLower(it.Nama), f.NamaLike) {
+			continue
+		}
+		if f.NamaPrefix != "" && !strings.HasPrefix(strings.ToLower(it.Nama), f.NamaPrefix) {
+			continue
+		}
+		if len(f.IDIn) > 0 && !containsID(f.IDIn, it.ID) {
+			continue
+		}
+		if f.HasChildrenGte != nil && it.ChildrenCount < *f.HasChildrenGte {
+			continue
+		}
+		if f.HasChildrenLte != nil && it.ChildrenCount > *f.HasChildrenLte {
+			continue
+		}
+		filtered = append(filtered, it)
+	}
+
+	switch f.Sort {
+	case "nama":
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Nama < filtered[j].Nama })
+	case "children_count":
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].ChildrenCount < filtered[j].ChildrenCount })
+	default:
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].ID < filtered[j].ID })
+	}
+	if f.Order == "desc" {
+		for i, j := 0, len(filtered)-1; i < j; i, j = i+1, j-1 {
+			filtered[i], filtered[j] = filtered[j], filtered[i]
+		}
+	}
+
+	total := len(filtered)
+	perPage := f.PerPage
+	if perPage <= 0 {
+		perPage = 25
+	}
+	pageNum := f.Page
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	start := (pageNum - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	return filtered[start:end], Meta{Total: total, Page: pageNum, PerPage: perPage}
+}
+
+func containsID(ids []string, id string) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
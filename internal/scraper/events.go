@@ -0,0 +1,103 @@
+package scraper
+
+import "sync"
+
+// eventHistoryLimit bounds how many past events the hub keeps around for
+// Last-Event-ID replay, so a long-running scrape doesn't grow this unbounded.
+const eventHistoryLimit = 256
+
+// Event is one pub/sub notification pushed to SSE subscribers. Type is one
+// of "started", "progress", "stopped", "error", or "finished"; Data carries
+// whatever payload makes sense for that type (GetProgress's snapshot for
+// "progress"/"finished", an "message" key for "error", empty otherwise).
+type Event struct {
+	ID   int64                  `json:"id"`
+	Type string                 `json:"type"`
+	Data map[string]interface{} `json:"data,omitempty"`
+}
+
+// eventHub is a small pub/sub broker so multiple concurrent SSE clients can
+// subscribe to a scraper's events without blocking each other or the
+// publisher: each subscriber gets its own buffered channel, and a slow
+// subscriber has events dropped rather than stalling ScrapeAll.
+type eventHub struct {
+	mu      sync.Mutex
+	nextID  int64
+	subs    map[chan Event]struct{}
+	history []Event
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subs: make(map[chan Event]struct{})}
+}
+
+// publish fans an event out to every current subscriber and records it in
+// the replay history used by Subscribe's lastEventID resume.
+func (h *eventHub) publish(eventType string, data map[string]interface{}) {
+	h.mu.Lock()
+	h.nextID++
+	ev := Event{ID: h.nextID, Type: eventType, Data: data}
+	h.history = append(h.history, ev)
+	if len(h.history) > eventHistoryLimit {
+		h.history = h.history[len(h.history)-eventHistoryLimit:]
+	}
+	chans := make([]chan Event, 0, len(h.subs))
+	for ch := range h.subs {
+		chans = append(chans, ch)
+	}
+	h.mu.Unlock()
+
+	for _, ch := range chans {
+		select {
+		case ch <- ev:
+		default:
+			// Subscriber isn't draining fast enough; drop rather than block
+			// the scrape loop that's publishing this event.
+		}
+	}
+}
+
+// Subscribe registers a new listener and returns its event channel plus an
+// unsubscribe func the caller must invoke exactly once (e.g. when its SSE
+// client disconnects) to release the channel. If lastEventID is > 0, any
+// buffered events with a higher ID are replayed on the returned channel
+// ahead of live events, so a briefly-disconnected browser resuming with
+// Last-Event-ID doesn't miss what happened in the gap.
+func (h *eventHub) Subscribe(lastEventID int64) (<-chan Event, func()) {
+	ch := make(chan Event, 32)
+
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	var replay []Event
+	if lastEventID > 0 {
+		for _, ev := range h.history {
+			if ev.ID > lastEventID {
+				replay = append(replay, ev)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	if len(replay) > 0 {
+		go func() {
+			for _, ev := range replay {
+				ch <- ev
+			}
+		}()
+	}
+
+	// unsubscribe only removes ch from subs; it deliberately never closes ch.
+	// publish and the replay goroutine above both send into ch without
+	// synchronizing with unsubscribe, so closing here would race a send on a
+	// closed channel (a panic, not just a dropped event). The SSE handler
+	// doesn't need the close either: it already stops on its own context's
+	// Done() rather than waiting for ch to report closed. Once removed from
+	// subs, ch receives no further events and is left for the garbage
+	// collector once the handler goroutine drops its reference.
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs, ch)
+	}
+	return ch, unsubscribe
+}
@@ -0,0 +1,392 @@
+package scraper
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"wilayah-api/internal/scraper/rules"
+)
+
+// JobID identifies one scrape job owned by a JobManager.
+type JobID string
+
+// JobStatus is a JobRecord's lifecycle state.
+type JobStatus string
+
+const (
+	JobRunning  JobStatus = "running"
+	JobStopped  JobStatus = "stopped"
+	JobError    JobStatus = "error"
+	JobFinished JobStatus = "finished"
+)
+
+// JobSpec describes one scrape job submitted to a JobManager: how many
+// workers to run it with and which subtree of the wilayah data to walk.
+// CronExpr is stored but not yet scheduled; it's a placeholder for a future
+// recurring-run scheduler.
+type JobSpec struct {
+	Workers  int      `json:"workers,omitempty"`
+	Only     []string `json:"only,omitempty"`     // provinsi IDs to scope to, e.g. Only: []string{"73"}
+	KabOnly  []string `json:"kab_only,omitempty"` // kabupaten IDs to scope to, e.g. KabOnly: []string{"7301"}
+	Exclude  []string `json:"exclude,omitempty"`
+	Priority int      `json:"priority,omitempty"` // reserved for a future queue; jobs currently run immediately on Submit
+	CronExpr string   `json:"cron_expr,omitempty"`
+
+	// RuleSet names a rule set loaded by the manager's *rules.Manager. When
+	// set, the job walks that RuleSet's extraction tree instead of the
+	// built-in Sipedas provinsi/kabupaten/kecamatan/desa pipeline, which is
+	// how the same binary targets a new upstream source without recompiling.
+	RuleSet string `json:"rule_set,omitempty"`
+	// DryRun, when RuleSet is set, logs the first dryRunSampleSize captures
+	// of the whole run instead of writing the job's output file; the sample
+	// is not grouped by rule.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// dryRunSampleSize caps how many captures --dry-run prints for the whole run
+// so a debugging run against a large tree doesn't flood the log.
+const dryRunSampleSize = 10
+
+// JobRecord is a JobSpec plus everything the manager tracks about its run.
+// It's the shape persisted to <outputDir>/jobs/<id>.json so job history
+// survives a process restart.
+type JobRecord struct {
+	ID             JobID     `json:"id"`
+	Spec           JobSpec   `json:"spec"`
+	SubmittedAt    time.Time `json:"submitted_at"`
+	Status         JobStatus `json:"status"`
+	CheckpointPath string    `json:"checkpoint_path,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// jobEntry pairs a JobRecord with the live Scraper running it. scraper is
+// nil for records reloaded from disk at startup, since the process that ran
+// them is gone.
+type jobEntry struct {
+	record  JobRecord
+	scraper *Scraper
+	// cancel stops a rule-set job (scraper is nil for those); unused for
+	// jobs running the built-in Sipedas pipeline, which stop via scraper.Stop.
+	cancel context.CancelFunc
+}
+
+// JobManager runs and tracks multiple independent Scraper instances keyed by
+// JobID, so a scoped re-scrape (e.g. one kabupaten) can run alongside a full
+// scrape instead of both contending for a single shared scraper.
+type JobManager struct {
+	mu        sync.Mutex
+	outputDir string
+	jobsDir   string
+	jobs      map[JobID]*jobEntry
+	rules     *rules.Manager
+}
+
+// NewJobManager creates a manager rooted at outputDir (each job writes under
+// outputDir/jobs/<id>) and loads any job records left behind in
+// outputDir/jobs by a previous process.
+func NewJobManager(outputDir string) (*JobManager, error) {
+	jm := &JobManager{
+		outputDir: outputDir,
+		jobsDir:   filepath.Join(outputDir, "jobs"),
+		jobs:      make(map[JobID]*jobEntry),
+	}
+	if err := os.MkdirAll(jm.jobsDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating jobs dir: %v", err)
+	}
+	if err := jm.loadExisting(); err != nil {
+		return nil, err
+	}
+	return jm, nil
+}
+
+// SetRulesManager attaches the *rules.Manager a JobSpec.RuleSet name is
+// resolved against. Submitting a RuleSet job before this is called fails.
+func (jm *JobManager) SetRulesManager(rm *rules.Manager) {
+	jm.mu.Lock()
+	jm.rules = rm
+	jm.mu.Unlock()
+}
+
+// Rules returns the manager attached via SetRulesManager, or nil if none was
+// set (e.g. scraper/rules doesn't exist in this deployment).
+func (jm *JobManager) Rules() *rules.Manager {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	return jm.rules
+}
+
+// loadExisting reads back job records from a previous process. Any record
+// still marked running when the process exited is relabeled stopped,
+// since there's no live Scraper left to ask.
+func (jm *JobManager) loadExisting() error {
+	entries, err := os.ReadDir(jm.jobsDir)
+	if err != nil {
+		return fmt.Errorf("error reading jobs dir: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(jm.jobsDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var rec JobRecord
+		if err := json.Unmarshal(data, &rec); err != nil {
+			continue
+		}
+		if rec.Status == JobRunning {
+			rec.Status = JobStopped
+		}
+		jm.jobs[rec.ID] = &jobEntry{record: rec}
+	}
+	return nil
+}
+
+func newJobID() JobID {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err == nil {
+		return JobID("job_" + hex.EncodeToString(b))
+	}
+	return JobID(fmt.Sprintf("job_%d", time.Now().UnixNano()))
+}
+
+// Submit starts a job for spec in the background and returns its JobID
+// immediately; progress can be followed via Get/List. If spec.RuleSet is
+// set, the job walks that rule set's extraction tree (see SetRulesManager);
+// otherwise it runs the built-in Sipedas pipeline scoped by Only/KabOnly/Exclude.
+func (jm *JobManager) Submit(spec JobSpec) (JobID, error) {
+	if spec.Workers <= 0 {
+		spec.Workers = 4
+	}
+	id := newJobID()
+
+	if spec.RuleSet != "" {
+		return jm.submitRuleSetJob(id, spec)
+	}
+
+	sc := NewScraper(ScraperConfig{
+		MaxWorkers: spec.Workers,
+		OutputDir:  filepath.Join(jm.jobsDir, string(id)),
+		Only:       spec.Only,
+		KabOnly:    spec.KabOnly,
+		Exclude:    spec.Exclude,
+	})
+
+	entry := &jobEntry{
+		record: JobRecord{
+			ID:          id,
+			Spec:        spec,
+			SubmittedAt: time.Now(),
+			Status:      JobRunning,
+		},
+		scraper: sc,
+	}
+
+	jm.mu.Lock()
+	jm.jobs[id] = entry
+	rec := entry.record
+	jm.mu.Unlock()
+
+	if err := jm.persist(rec); err != nil {
+		return id, err
+	}
+
+	go func() {
+		err := sc.ScrapeAll()
+
+		jm.mu.Lock()
+		switch {
+		case err != nil:
+			entry.record.Status = JobError
+			entry.record.Error = err.Error()
+		case sc.FinalFilePath() != "":
+			entry.record.Status = JobFinished
+			entry.record.CheckpointPath = sc.FinalFilePath()
+		default:
+			entry.record.Status = JobStopped
+		}
+		rec := entry.record
+		jm.mu.Unlock()
+
+		jm.persist(rec)
+	}()
+
+	return id, nil
+}
+
+// submitRuleSetJob runs spec.RuleSet's extraction tree against the rule
+// manager set via SetRulesManager. DryRun logs a sample of captures instead
+// of writing the job's output file, mirroring the scraper binary's
+// --dry-run flag.
+func (jm *JobManager) submitRuleSetJob(id JobID, spec JobSpec) (JobID, error) {
+	rm := jm.Rules()
+	if rm == nil {
+		return "", fmt.Errorf("no rules manager configured")
+	}
+	rs, ok := rm.Get(spec.RuleSet)
+	if !ok {
+		return "", fmt.Errorf("rule set %q not found", spec.RuleSet)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	entry := &jobEntry{
+		record: JobRecord{
+			ID:          id,
+			Spec:        spec,
+			SubmittedAt: time.Now(),
+			Status:      JobRunning,
+		},
+		cancel: cancel,
+	}
+
+	jm.mu.Lock()
+	jm.jobs[id] = entry
+	rec := entry.record
+	jm.mu.Unlock()
+
+	if err := jm.persist(rec); err != nil {
+		cancel()
+		return id, err
+	}
+
+	go func() {
+		defer cancel()
+
+		captures, err := rules.Execute(ctx, http.DefaultClient, rs.Root, rules.Capture{})
+
+		jm.mu.Lock()
+		switch {
+		case err != nil:
+			entry.record.Status = JobError
+			entry.record.Error = err.Error()
+		default:
+			entry.record.Status = JobFinished
+		}
+		rec := entry.record
+		jm.mu.Unlock()
+
+		if err == nil {
+			if spec.DryRun {
+				sample := captures
+				if len(sample) > dryRunSampleSize {
+					sample = sample[:dryRunSampleSize]
+				}
+				log.Printf("scraper: dry-run %s/%s: %d captures (showing %d): %v", spec.RuleSet, rs.Root.Name, len(captures), len(sample), sample)
+			} else if werr := jm.saveRuleSetOutput(id, captures); werr != nil {
+				jm.mu.Lock()
+				entry.record.Status = JobError
+				entry.record.Error = werr.Error()
+				rec = entry.record
+				jm.mu.Unlock()
+			} else {
+				jm.mu.Lock()
+				entry.record.CheckpointPath = jm.ruleSetOutputPath(id)
+				rec = entry.record
+				jm.mu.Unlock()
+			}
+		}
+
+		jm.persist(rec)
+	}()
+
+	return id, nil
+}
+
+func (jm *JobManager) ruleSetOutputPath(id JobID) string {
+	return filepath.Join(jm.jobsDir, string(id)+"_captures.json")
+}
+
+func (jm *JobManager) saveRuleSetOutput(id JobID, captures []rules.Capture) error {
+	data, err := json.MarshalIndent(captures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(jm.ruleSetOutputPath(id), data, 0644)
+}
+
+// persist writes rec to <jobsDir>/<id>.json, overwriting any previous
+// snapshot of the same job.
+func (jm *JobManager) persist(rec JobRecord) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(jm.jobsDir, string(rec.ID)+".json")
+	return os.WriteFile(path, data, 0644)
+}
+
+// Cancel stops the job's scraper (or, for a RuleSet job, cancels its
+// context). It returns an error if the job is unknown or if it was loaded
+// from a previous process and has nothing live in this process to signal.
+func (jm *JobManager) Cancel(id JobID) error {
+	jm.mu.Lock()
+	entry, ok := jm.jobs[id]
+	jm.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("job %s not found", id)
+	}
+	switch {
+	case entry.scraper != nil:
+		entry.scraper.Stop()
+	case entry.cancel != nil:
+		entry.cancel()
+	default:
+		return fmt.Errorf("job %s is not running in this process", id)
+	}
+	return nil
+}
+
+// Get returns a snapshot of a job's record.
+func (jm *JobManager) Get(id JobID) (JobRecord, bool) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	entry, ok := jm.jobs[id]
+	if !ok {
+		return JobRecord{}, false
+	}
+	return entry.record, true
+}
+
+// List returns a snapshot of every known job's record, oldest first.
+func (jm *JobManager) List() []JobRecord {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	out := make([]JobRecord, 0, len(jm.jobs))
+	for _, entry := range jm.jobs {
+		out = append(out, entry.record)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SubmittedAt.Before(out[j].SubmittedAt)
+	})
+	return out
+}
+
+// Latest returns the most recently submitted job's record and its live
+// Scraper, for back-compat endpoints that operate on "the" scraper rather
+// than a specific job ID. scraper is nil if that job was loaded from a
+// previous process.
+func (jm *JobManager) Latest() (JobRecord, *Scraper, bool) {
+	jm.mu.Lock()
+	defer jm.mu.Unlock()
+	var latest *jobEntry
+	for _, entry := range jm.jobs {
+		if latest == nil || entry.record.SubmittedAt.After(latest.record.SubmittedAt) {
+			latest = entry
+		}
+	}
+	if latest == nil {
+		return JobRecord{}, nil, false
+	}
+	return latest.record, latest.scraper, true
+}
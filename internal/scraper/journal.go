@@ -0,0 +1,116 @@
+package scraper
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// journalEntry is one line of the append-only journal: either a completed
+// kecamatan (written as soon as its desa are fetched) or a completed
+// kabupaten (written once all of its kecamatan are done). Replaying the
+// journal on resume lets processKabupatenParallel/processKabupaten skip work
+// that already finished, even if the process was killed mid-province.
+type journalEntry struct {
+	Level   string     `json:"level"` // "kab" or "kec"
+	Pro     string     `json:"pro"`
+	Kab     string     `json:"kab"`
+	Kec     *Kecamatan `json:"kec,omitempty"`
+	KabData *Kabupaten `json:"kab_data,omitempty"`
+}
+
+// journal is an append-only, crash-safe log of completed kab/kec nodes for
+// the current run. Appends are flushed immediately so a Ctrl+C right after a
+// write still has it durably on disk.
+type journal struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newJournal(path string) (*journal, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &journal{file: f}, nil
+}
+
+func (j *journal) appendKec(pro, kab string, kec Kecamatan) error {
+	return j.append(journalEntry{Level: "kec", Pro: pro, Kab: kab, Kec: &kec})
+}
+
+func (j *journal) appendKab(pro string, kab Kabupaten) error {
+	return j.append(journalEntry{Level: "kab", Pro: pro, Kab: kab.ID, KabData: &kab})
+}
+
+func (j *journal) append(entry journalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := j.file.Write(data); err != nil {
+		return err
+	}
+	return j.file.Sync()
+}
+
+func (j *journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// journalState is the replayed result of a journal: completed kabupaten
+// (fully done, including their kecamatan) keyed by "pro/kab", and completed
+// kecamatan for kabupaten still in progress, keyed by "pro/kab" -> kecID.
+type journalState struct {
+	completedKab map[string]Kabupaten
+	completedKec map[string]map[string]Kecamatan
+}
+
+func loadJournal(path string) (*journalState, error) {
+	state := &journalState{
+		completedKab: make(map[string]Kabupaten),
+		completedKec: make(map[string]map[string]Kecamatan),
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A truncated last line (killed mid-write) is expected; skip it.
+			continue
+		}
+
+		key := entry.Pro + "/" + entry.Kab
+		switch entry.Level {
+		case "kab":
+			if entry.KabData != nil {
+				state.completedKab[key] = *entry.KabData
+			}
+		case "kec":
+			if entry.Kec != nil {
+				if state.completedKec[key] == nil {
+					state.completedKec[key] = make(map[string]Kecamatan)
+				}
+				state.completedKec[key][entry.Kec.ID] = *entry.Kec
+			}
+		}
+	}
+	return state, scanner.Err()
+}
@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tidwall/gjson"
+)
+
+// Capture is one set of values pulled out of a rule's response, keyed by
+// capture name. A leaf rule's Capture has its ancestors' captures merged in
+// ahead of its own, so e.g. a desa-level result carries pro/kab/kec
+// alongside desa.
+type Capture map[string]string
+
+// Client fetches a rule's resolved URL. *http.Client satisfies it in
+// production; tests can stub http.RoundTripper via &http.Client{Transport: ...}.
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Execute walks rule and its children starting from vars (the captures
+// accumulated from rule's ancestors; empty for the tree root), fetching
+// rule's URLTemplate and extracting CaptureGroups from the response. It
+// returns one Capture per row extracted by a leaf rule, each carrying the
+// full chain of ancestor captures merged in.
+func Execute(ctx context.Context, client Client, rule Rule, vars Capture) ([]Capture, error) {
+	url := renderTemplate(rule.URLTemplate, vars)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building request for rule %s: %v", rule.Name, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching rule %s: %v", rule.Name, err)
+	}
+	defer resp.Body.Close()
+
+	rows, err := extractRows(rule, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting rule %s: %v", rule.Name, err)
+	}
+
+	var results []Capture
+	for _, row := range rows {
+		merged := mergeCapture(vars, row)
+		if len(rule.Children) == 0 {
+			results = append(results, merged)
+			continue
+		}
+		for _, child := range rule.Children {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			childResults, err := Execute(ctx, client, child, merged)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, childResults...)
+		}
+	}
+	return results, nil
+}
+
+func mergeCapture(parent, row Capture) Capture {
+	merged := make(Capture, len(parent)+len(row))
+	for k, v := range parent {
+		merged[k] = v
+	}
+	for k, v := range row {
+		merged[k] = v
+	}
+	return merged
+}
+
+// renderTemplate replaces each `{{.name}}` placeholder in tmpl with the
+// matching capture from vars.
+func renderTemplate(tmpl string, vars Capture) string {
+	out := tmpl
+	for k, v := range vars {
+		out = strings.ReplaceAll(out, "{{."+k+"}}", v)
+	}
+	return out
+}
+
+func extractRows(rule Rule, body io.Reader) ([]Capture, error) {
+	if rule.Type == "json" {
+		return extractJSON(rule, body)
+	}
+	return extractHTML(rule, body)
+}
+
+func extractHTML(rule Rule, body io.Reader) ([]Capture, error) {
+	doc, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Capture
+	doc.Find(rule.Selector).Each(func(_ int, sel *goquery.Selection) {
+		row := make(Capture, len(rule.CaptureGroups))
+		for _, name := range rule.CaptureGroups {
+			if attr, ok := sel.Attr(name); ok {
+				row[name] = attr
+				continue
+			}
+			row[name] = strings.TrimSpace(sel.Text())
+		}
+		rows = append(rows, row)
+	})
+	return rows, nil
+}
+
+func extractJSON(rule Rule, body io.Reader) ([]Capture, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Capture
+	gjson.GetBytes(data, rule.Selector).ForEach(func(_, value gjson.Result) bool {
+		row := make(Capture, len(rule.CaptureGroups))
+		for _, name := range rule.CaptureGroups {
+			row[name] = value.Get(name).String()
+		}
+		rows = append(rows, row)
+		return true
+	})
+	return rows, nil
+}
@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripperFunc adapts a func to http.RoundTripper so tests can stub
+// Client without spinning up a real server.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func stubClient(body string, contentType string) *http.Client {
+	return &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp := &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}
+			resp.Header.Set("Content-Type", contentType)
+			return resp, nil
+		}),
+	}
+}
+
+func TestExecuteHTML(t *testing.T) {
+	html := `
+		<table>
+			<tr class="row" data-id="11"><td>Aceh</td></tr>
+			<tr class="row" data-id="12"><td>Sumut</td></tr>
+		</table>
+	`
+	client := stubClient(html, "text/html")
+
+	rule := Rule{
+		Name:          "provinsi",
+		URLTemplate:   "https://example.test/provinsi",
+		Type:          "html",
+		Selector:      "tr.row",
+		CaptureGroups: []string{"data-id"},
+	}
+
+	results, err := Execute(context.Background(), client, rule, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %+v", len(results), results)
+	}
+	if results[0]["data-id"] != "11" || results[1]["data-id"] != "12" {
+		t.Fatalf("unexpected captures: %+v", results)
+	}
+}
+
+func TestExecuteJSON(t *testing.T) {
+	body := `[{"id":"11","nama":"Aceh"},{"id":"12","nama":"Sumut"}]`
+	client := stubClient(body, "application/json")
+
+	rule := Rule{
+		Name:          "provinsi",
+		URLTemplate:   "https://example.test/provinsi.json",
+		Type:          "json",
+		Selector:      "@this",
+		CaptureGroups: []string{"id", "nama"},
+	}
+
+	results, err := Execute(context.Background(), client, rule, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %+v", len(results), results)
+	}
+	if results[0]["nama"] != "Aceh" || results[1]["nama"] != "Sumut" {
+		t.Fatalf("unexpected captures: %+v", results)
+	}
+}
+
+func TestExecuteChildMergesParentCaptures(t *testing.T) {
+	requestedURLs := make([]string, 0, 2)
+
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requestedURLs = append(requestedURLs, req.URL.String())
+			var body string
+			if strings.Contains(req.URL.String(), "/provinsi") {
+				body = `<li class="row" data-id="11">Aceh</li>`
+			} else {
+				body = `<li class="row" data-id="01">Kab A</li>`
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+
+	rule := Rule{
+		Name:          "provinsi",
+		URLTemplate:   "https://example.test/provinsi",
+		Type:          "html",
+		Selector:      "li.row",
+		CaptureGroups: []string{"data-id"},
+		Children: []Rule{
+			{
+				Name:          "kabupaten",
+				URLTemplate:   "https://example.test/{{.data-id}}/kabupaten",
+				Type:          "html",
+				Selector:      "li.row",
+				CaptureGroups: []string{"data-id"},
+			},
+		},
+	}
+
+	results, err := Execute(context.Background(), client, rule, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 leaf result, got %d: %+v", len(results), results)
+	}
+	if results[0]["data-id"] != "01" {
+		t.Fatalf("expected leaf capture to overwrite parent's, got %+v", results[0])
+	}
+	if requestedURLs[1] != "https://example.test/11/kabupaten" {
+		t.Fatalf("expected child URL to be rendered from parent capture, got %q", requestedURLs[1])
+	}
+}
@@ -0,0 +1,41 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LoadDir reads every *.yaml file in dir as a RuleSet keyed by its Name
+// field, so a hot reload can swap the whole directory in atomically.
+func LoadDir(dir string) (map[string]*RuleSet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rules dir: %v", err)
+	}
+
+	sets := make(map[string]*RuleSet)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", entry.Name(), err)
+		}
+
+		var rs RuleSet
+		if err := yaml.Unmarshal(data, &rs); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", entry.Name(), err)
+		}
+		if rs.Name == "" {
+			rs.Name = strings.TrimSuffix(entry.Name(), ".yaml")
+		}
+		sets[rs.Name] = &rs
+	}
+	return sets, nil
+}
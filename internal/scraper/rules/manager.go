@@ -0,0 +1,53 @@
+package rules
+
+import "sync"
+
+// Manager holds the currently loaded rule sets and lets
+// POST /scraper/rules/reload swap in an edited scraper/rules/*.yaml without
+// restarting the process.
+type Manager struct {
+	mu   sync.RWMutex
+	dir  string
+	sets map[string]*RuleSet
+}
+
+// NewManager loads every rule set under dir and returns a Manager serving
+// them. It errors if dir can't be read, matching LoadDir.
+func NewManager(dir string) (*Manager, error) {
+	m := &Manager{dir: dir}
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Reload re-reads dir and swaps the loaded rule sets in atomically.
+func (m *Manager) Reload() error {
+	sets, err := LoadDir(m.dir)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.sets = sets
+	m.mu.Unlock()
+	return nil
+}
+
+// Get looks up a loaded rule set by name.
+func (m *Manager) Get(name string) (*RuleSet, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	rs, ok := m.sets[name]
+	return rs, ok
+}
+
+// List returns every currently loaded rule set.
+func (m *Manager) List() []*RuleSet {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]*RuleSet, 0, len(m.sets))
+	for _, rs := range m.sets {
+		out = append(out, rs)
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+// Package rules implements a configurable, hot-reloadable extraction tree so
+// the scraper binary can be pointed at a new upstream source (BPS,
+// Kemendagri, etc.) by dropping a YAML file under scraper/rules/ instead of
+// recompiling against a new provider.Provider implementation.
+package rules
+
+// Rule describes one node in an extraction tree. It fetches URLTemplate
+// (with any `{{.name}}` placeholders resolved from the captures accumulated
+// by its ancestors), applies Selector to the response to pull
+// CaptureGroups out of it, and feeds each resulting row into every child's
+// own URLTemplate. Type picks the extraction strategy: "html" selects
+// elements with a CSS selector via goquery; "json" selects an array with a
+// GJSON path.
+type Rule struct {
+	Name          string   `yaml:"name"`
+	URLTemplate   string   `yaml:"url_template"`
+	Selector      string   `yaml:"selector"`
+	Type          string   `yaml:"type"` // "html" or "json"
+	CaptureGroups []string `yaml:"capture_groups"`
+	Children      []Rule   `yaml:"children,omitempty"`
+}
+
+// RuleSet is a named, independently loadable extraction tree. Name falls
+// back to the source filename (minus extension) when left blank in YAML.
+type RuleSet struct {
+	Name string `yaml:"name"`
+	Root Rule   `yaml:"root"`
+}
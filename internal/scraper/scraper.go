@@ -2,17 +2,24 @@ package scraper
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"wilayah-api/internal/fetcher"
+	"wilayah-api/internal/logging"
+	"wilayah-api/internal/metrics"
+	"wilayah-api/internal/progress"
+	"wilayah-api/internal/provider"
 )
 
 // Data structures
@@ -31,6 +38,11 @@ type Kabupaten struct {
 	ID   string      `json:"id"`
 	Nama string      `json:"nama"`
 	Kec  []Kecamatan `json:"kec"`
+	// Hash fingerprints this kabupaten's kecamatan id/nama set at scrape
+	// time. A later incremental run can re-fetch just the (cheap)
+	// kecamatan list, compare hashes, and skip re-walking the full
+	// kec/desa subtree when nothing underneath actually changed.
+	Hash string `json:"hash,omitempty"`
 }
 
 type Provinsi struct {
@@ -48,6 +60,12 @@ type ScraperState struct {
 	currentData    *WilayahData
 	checkpointFile string
 	tempFile       string
+	finalFile      string
+	journalFile    string
+	journal        *journal
+	cacheFile      string
+	cache          *fetcher.ResponseCache
+	reporter       *progress.Reporter
 	isRunning      bool
 	ctx            context.Context
 	cancel         context.CancelFunc
@@ -55,16 +73,27 @@ type ScraperState struct {
 }
 
 type ScraperConfig struct {
-	MaxWorkers int
-	OutputDir  string
-	BaseURL    string
-	Year       int
+	MaxWorkers  int
+	OutputDir   string
+	BaseURL     string
+	Year        int
+	RPS         float64           // requests/sec shared across all workers (0 = unlimited)
+	MaxRetries  int               // HTTP retry attempts on 429/5xx/network errors
+	BackoffBase time.Duration     // base delay for exponential backoff
+	Progress    progress.Mode     // how to render scrape progress (default: Plain)
+	Provider    provider.Provider // data source to walk; defaults to a SipedasProvider against BaseURL
+	Only        []string          // if non-empty, only walk provinsi with one of these IDs
+	Exclude     []string          // skip provinsi with one of these IDs, applied after Only
+	KabOnly     []string          // if non-empty, only walk kabupaten with one of these IDs (scopes a re-scrape below the provinsi level, e.g. JobManager's "kab=7301")
+	Logger      logging.Logger    // receives operational messages; defaults to JSON logging on stderr
 }
 
 type Scraper struct {
-	config     ScraperConfig
-	state      *ScraperState
-	httpClient *http.Client
+	config   ScraperConfig
+	state    *ScraperState
+	provider provider.Provider
+	logger   logging.Logger
+	events   *eventHub
 }
 
 // NewScraper creates a new scraper instance
@@ -81,14 +110,89 @@ func NewScraper(config ScraperConfig) *Scraper {
 	if config.Year == 0 {
 		config.Year = time.Now().Year()
 	}
+	if config.Logger == nil {
+		config.Logger = logging.NewJSON(os.Stderr)
+	}
+
+	prov := config.Provider
+	if prov == nil {
+		prov = provider.NewSipedasProvider(config.BaseURL, fetcher.NewClient(fetcher.Config{
+			RPS:         config.RPS,
+			MaxRetries:  config.MaxRetries,
+			BackoffBase: config.BackoffBase,
+			OnRetry:     metrics.RecordRetry,
+		}))
+	}
 
 	return &Scraper{
-		config: config,
-		state:  &ScraperState{},
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		config:   config,
+		state:    &ScraperState{},
+		provider: prov,
+		logger:   config.Logger,
+		events:   newEventHub(),
+	}
+}
+
+// Subscribe attaches a new listener to this scraper's event stream (started,
+// progress, stopped, error, finished). Pass lastEventID (from an SSE
+// Last-Event-ID header) to replay anything published since that ID before
+// the channel switches to live events; pass 0 for a fresh subscription. The
+// returned func unsubscribes and must be called when the caller is done
+// (e.g. its HTTP client disconnected) so the channel is released.
+func (s *Scraper) Subscribe(lastEventID int64) (<-chan Event, func()) {
+	return s.events.Subscribe(lastEventID)
+}
+
+// filterProvinsiConfig applies config.Only/config.Exclude to a provinsi id ->
+// nama map. An empty Only means "all provinsi"; Exclude is applied after
+// Only so the two compose (e.g. Only every province but Exclude one of them).
+func (s *Scraper) filterProvinsiConfig(data map[string]string) map[string]string {
+	if len(s.config.Only) == 0 && len(s.config.Exclude) == 0 {
+		return data
+	}
+
+	only := make(map[string]bool, len(s.config.Only))
+	for _, id := range s.config.Only {
+		only[id] = true
+	}
+	exclude := make(map[string]bool, len(s.config.Exclude))
+	for _, id := range s.config.Exclude {
+		exclude[id] = true
+	}
+
+	filtered := make(map[string]string, len(data))
+	for id, nama := range data {
+		if len(only) > 0 && !only[id] {
+			continue
+		}
+		if exclude[id] {
+			continue
+		}
+		filtered[id] = nama
+	}
+	return filtered
+}
+
+// filterKabupatenConfig applies config.KabOnly to provID's kabupaten id ->
+// nama map. KabOnly holds full 4-digit codes (provID + kabupaten id, e.g.
+// "7301"). An empty KabOnly means "all kabupaten in this provinsi".
+func (s *Scraper) filterKabupatenConfig(provID string, data map[string]string) map[string]string {
+	if len(s.config.KabOnly) == 0 {
+		return data
+	}
+
+	only := make(map[string]bool, len(s.config.KabOnly))
+	for _, id := range s.config.KabOnly {
+		only[id] = true
+	}
+
+	filtered := make(map[string]string, len(data))
+	for id, nama := range data {
+		if only[provID+id] {
+			filtered[id] = nama
+		}
 	}
+	return filtered
 }
 
 // SetupSignalHandler sets up graceful shutdown
@@ -107,11 +211,11 @@ func (s *Scraper) handleShutdown() {
 	defer s.state.mu.Unlock()
 
 	if !s.state.isRunning {
-		fmt.Println("\n⚠️ Script sedang tidak berjalan, keluar...")
+		s.logger.Warn("shutdown requested while scraper is not running")
 		os.Exit(0)
 	}
 
-	fmt.Println("\n🛑 Mendeteksi Ctrl+C, menghentikan threads dan menyimpan checkpoint...")
+	s.logger.Warn("interrupt received, stopping workers and saving checkpoint")
 
 	// Cancel context to stop all goroutines
 	if s.state.cancel != nil {
@@ -120,10 +224,10 @@ func (s *Scraper) handleShutdown() {
 
 	// Save checkpoint
 	if s.state.currentData != nil && s.state.checkpointFile != "" {
-		if err := s.safeCheckpointSave(s.state.currentData, s.state.checkpointFile, "Disimpan karena script dihentikan paksa"); err != nil {
-			fmt.Printf("❌ Error saving checkpoint: %v\n", err)
+		if err := s.safeCheckpointSave(s.state.currentData, s.state.checkpointFile, "interrupted"); err != nil {
+			s.logger.Error("checkpoint save failed", "error", err)
 		} else {
-			fmt.Printf("💾 Checkpoint disimpan: %s\n", s.state.checkpointFile)
+			s.logger.Info("checkpoint saved", "path", s.state.checkpointFile)
 		}
 	}
 
@@ -131,96 +235,51 @@ func (s *Scraper) handleShutdown() {
 		s.saveToFile(s.state.currentData, s.state.tempFile)
 	}
 
-	fmt.Println("🔄 Jalankan ulang script untuk melanjutkan dari posisi terakhir")
-	s.state.isRunning = false
-	fmt.Println("👋 Script dihentikan dengan aman")
-	os.Exit(0)
-}
-
-func (s *Scraper) getJSON(endpoint string, params map[string]interface{}) (map[string]interface{}, error) {
-	req, err := http.NewRequest("GET", s.config.BaseURL+endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Add query parameters
-	q := req.URL.Query()
-	for key, value := range params {
-		q.Add(key, fmt.Sprintf("%v", value))
-	}
-	req.URL.RawQuery = q.Encode()
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, err
-	}
-
-	return s.normalizeData(result), nil
-}
-
-func (s *Scraper) normalizeText(text string) string {
-	replacements := map[string]string{
-		"\\'":     "'",
-		"\\\"":    "\"",
-		"\\\\":    "\\",
-		"\\/":     "/",
-		"\\u0027": "'",
-		"\\u0022": "\"",
+	if s.state.cache != nil {
+		if err := s.state.cache.Save(); err != nil {
+			s.logger.Error("response cache save failed", "error", err)
+		}
 	}
 
-	for old, new := range replacements {
-		text = strings.ReplaceAll(text, old, new)
+	if s.state.journal != nil {
+		s.state.journal.Close()
 	}
 
-	return text
-}
-
-func (s *Scraper) normalizeData(data map[string]interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-
-	for key, value := range data {
-		switch v := value.(type) {
-		case string:
-			result[key] = s.normalizeText(v)
-		case map[string]interface{}:
-			result[key] = s.normalizeData(v)
-		default:
-			result[key] = value
-		}
-	}
-
-	return result
+	s.logger.Info("rerun the scrape command to resume; completed kabupaten/kecamatan are skipped")
+	s.state.isRunning = false
+	s.logger.Info("scraper stopped safely")
+	os.Exit(0)
 }
 
+// saveToFile writes data as indented JSON to filename. The write goes to a
+// temp file in the same directory first and is published via os.Rename, so a
+// Ctrl+C mid-encode can never leave a half-written checkpoint behind.
 func (s *Scraper) saveToFile(data interface{}, filename string) error {
-	// Create directory if it doesn't exist
 	dir := filepath.Dir(filename)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
-	file, err := os.Create(filename)
+	tmp, err := os.CreateTemp(dir, filepath.Base(filename)+".tmp-*")
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once the rename below succeeds
 
-	encoder := json.NewEncoder(file)
+	encoder := json.NewEncoder(tmp)
 	encoder.SetIndent("", "  ")
 	encoder.SetEscapeHTML(false)
 
-	return encoder.Encode(data)
+	if err := encoder.Encode(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpName, filename)
 }
 
 func (s *Scraper) loadCheckpoint(checkpointFile string) (*WilayahData, error) {
@@ -239,8 +298,7 @@ func (s *Scraper) loadCheckpoint(checkpointFile string) (*WilayahData, error) {
 		return nil, err
 	}
 
-	fmt.Printf("📂 Checkpoint ditemukan: %s\n", checkpointFile)
-	fmt.Printf("   - Provinsi yang sudah diproses: %d\n", len(data.Pro))
+	s.logger.Info("checkpoint found", "path", checkpointFile, "provinsi_done", len(data.Pro))
 
 	return &data, nil
 }
@@ -249,16 +307,17 @@ func (s *Scraper) safeCheckpointSave(data *WilayahData, checkpointFile, progress
 	if err := s.saveToFile(data, checkpointFile); err != nil {
 		return err
 	}
+	metrics.RecordCheckpointSave()
 
 	if progressInfo != "" {
-		fmt.Printf("💾 Checkpoint disimpan: %s\n", progressInfo)
+		s.logger.Info("checkpoint saved", "path", checkpointFile, "detail", progressInfo)
 	}
 
 	return nil
 }
 
 // ScrapeAll performs the main scraping operation
-func (s *Scraper) ScrapeAll() error {
+func (s *Scraper) ScrapeAll() (err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	s.state.mu.Lock()
 	s.state.ctx = ctx
@@ -266,10 +325,21 @@ func (s *Scraper) ScrapeAll() error {
 	s.state.isRunning = true
 	s.state.mu.Unlock()
 
+	s.events.publish("started", nil)
+
 	defer func() {
 		s.state.mu.Lock()
 		s.state.isRunning = false
 		s.state.mu.Unlock()
+
+		switch {
+		case err != nil:
+			s.events.publish("error", map[string]interface{}{"message": err.Error()})
+		case ctx.Err() != nil:
+			s.events.publish("stopped", nil)
+		default:
+			s.events.publish("finished", s.GetProgress())
+		}
 	}()
 
 	// Create output directories
@@ -283,10 +353,64 @@ func (s *Scraper) ScrapeAll() error {
 	checkpointFile := filepath.Join(s.config.OutputDir, "checkpoints", fmt.Sprintf("checkpoint_%s.json", dateStr))
 	tempFile := filepath.Join(s.config.OutputDir, fmt.Sprintf("temp_wilayah_%s.json", timestamp))
 	finalFile := filepath.Join(s.config.OutputDir, fmt.Sprintf("wilayah_final_%s.json", dateStr))
+	journalFile := filepath.Join(s.config.OutputDir, "checkpoints", fmt.Sprintf("journal_%s.ndjson", dateStr))
+
+	// Replay the journal so kabupaten/kecamatan finished since the last
+	// checkpoint save aren't re-fetched after a kill mid-province.
+	journalState, err := loadJournal(journalFile)
+	if err != nil {
+		return fmt.Errorf("error loading journal: %v", err)
+	}
+	jrnl, err := newJournal(journalFile)
+	if err != nil {
+		return fmt.Errorf("error opening journal: %v", err)
+	}
+	defer jrnl.Close()
+
+	// Response cache so a resumed run doesn't re-hit the API for
+	// endpoint/param combinations already fetched before the last checkpoint.
+	cacheFile := filepath.Join(s.config.OutputDir, "checkpoints", fmt.Sprintf("cache_%s.json", dateStr))
+	cache, err := fetcher.NewResponseCache(cacheFile)
+	if err != nil {
+		return fmt.Errorf("error loading response cache: %v", err)
+	}
+	if cs, ok := s.provider.(interface {
+		SetCache(*fetcher.ResponseCache)
+	}); ok {
+		cs.SetCache(cache)
+	}
+
+	reporter := progress.NewReporter(s.config.Progress)
+	reporter.Start()
+	defer reporter.Stop()
+
+	// Push a progress event to SSE subscribers on a fixed tick rather than on
+	// every counter increment; GetProgress() is cheap but the per-kecamatan
+	// call rate is high enough that per-increment publishing would swamp
+	// slow subscribers for no real benefit.
+	progressTickerDone := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-progressTickerDone:
+				return
+			case <-ticker.C:
+				s.events.publish("progress", s.GetProgress())
+			}
+		}
+	}()
+	defer close(progressTickerDone)
 
 	s.state.mu.Lock()
 	s.state.checkpointFile = checkpointFile
 	s.state.tempFile = tempFile
+	s.state.journalFile = journalFile
+	s.state.journal = jrnl
+	s.state.cacheFile = cacheFile
+	s.state.cache = cache
+	s.state.reporter = reporter
 	s.state.mu.Unlock()
 
 	// Load checkpoint
@@ -299,15 +423,16 @@ func (s *Scraper) ScrapeAll() error {
 	s.state.currentData = allData
 	s.state.mu.Unlock()
 
-	fmt.Println("📌 Mengambil data provinsi...")
-	fmt.Println("💡 Tekan Ctrl+C untuk menghentikan dan menyimpan checkpoint")
-	fmt.Printf("🧵 Menggunakan %d thread untuk parallel processing\n", s.config.MaxWorkers)
+	reporter.Log("📌 Mengambil data provinsi...")
+	reporter.Log("💡 Tekan Ctrl+C untuk menghentikan dan menyimpan checkpoint")
+	reporter.Log("🧵 Menggunakan %d thread untuk parallel processing", s.config.MaxWorkers)
 
 	// Get provinces
-	provinsiData, err := s.getJSON("list_pro", map[string]interface{}{"thn": s.config.Year})
+	provinsiData, err := s.provider.ListProvinsi(ctx, s.config.Year)
 	if err != nil {
 		return fmt.Errorf("error getting provinces: %v", err)
 	}
+	provinsiData = s.filterProvinsiConfig(provinsiData)
 
 	// Convert to slice of key-value pairs
 	var provinsiItems []struct {
@@ -319,7 +444,7 @@ func (s *Scraper) ScrapeAll() error {
 		provinsiItems = append(provinsiItems, struct {
 			ID   string
 			Nama string
-		}{ID: id, Nama: nama.(string)})
+		}{ID: id, Nama: nama})
 	}
 
 	// Filter already processed provinces
@@ -340,7 +465,8 @@ func (s *Scraper) ScrapeAll() error {
 	}
 
 	// Process provinces
-	fmt.Printf("📊 Memproses %d provinsi yang belum selesai...\n", len(filteredProvinsi))
+	reporter.SetTotalProvinsi(len(filteredProvinsi))
+	reporter.Log("📊 Memproses %d provinsi yang belum selesai...", len(filteredProvinsi))
 	for i, prov := range filteredProvinsi {
 		select {
 		case <-ctx.Done():
@@ -348,14 +474,16 @@ func (s *Scraper) ScrapeAll() error {
 		default:
 		}
 
-		fmt.Printf("  ▶️ [%d/%d] Mengambil kabupaten di provinsi '%s'...\n", i+1, len(filteredProvinsi), prov.Nama)
+		reporter.Log("  ▶️ [%d/%d] Mengambil kabupaten di provinsi '%s'...", i+1, len(filteredProvinsi), prov.Nama)
 
 		// Get kabupaten
-		kabupatenData, err := s.getJSON("list_kab", map[string]interface{}{"thn": s.config.Year, "pro": prov.ID})
+		kabupatenData, err := s.provider.ListKabupaten(ctx, s.config.Year, prov.ID)
 		if err != nil {
-			fmt.Printf("❌ Error getting kabupaten for %s: %v\n", prov.Nama, err)
+			metrics.ScrapeErrorsTotal.WithLabelValues("prov").Inc()
+			reporter.Log("❌ Error getting kabupaten for %s: %v", prov.Nama, err)
 			continue
 		}
+		kabupatenData = s.filterKabupatenConfig(prov.ID, kabupatenData)
 
 		// Process kabupaten in parallel
 		var kabupatenItems []struct {
@@ -367,10 +495,10 @@ func (s *Scraper) ScrapeAll() error {
 			kabupatenItems = append(kabupatenItems, struct {
 				ID   string
 				Nama string
-			}{ID: id, Nama: nama.(string)})
+			}{ID: id, Nama: nama})
 		}
 
-		kabupatenResults := s.processKabupatenParallel(ctx, kabupatenItems, prov.ID, prov.Nama)
+		kabupatenResults := s.processKabupatenParallel(ctx, kabupatenItems, prov.ID, prov.Nama, journalState, jrnl, reporter)
 
 		// Update data
 		newProv := Provinsi{
@@ -382,12 +510,18 @@ func (s *Scraper) ScrapeAll() error {
 		s.state.mu.Lock()
 		allData.Pro = append(allData.Pro, newProv)
 		s.state.mu.Unlock()
+		metrics.ProvinsiProcessed.Set(float64(len(allData.Pro)))
+		metrics.ItemsTotal.WithLabelValues("prov").Inc()
+		reporter.ProvinsiDone()
 
 		// Save checkpoint
 		s.safeCheckpointSave(allData, checkpointFile, fmt.Sprintf("Provinsi %s selesai", prov.Nama))
 		s.saveToFile(allData, tempFile)
+		if err := cache.Save(); err != nil {
+			reporter.Log("⚠️ Gagal menyimpan response cache: %v", err)
+		}
 
-		fmt.Printf("✅ Provinsi %s selesai (%d/%d)\n", prov.Nama, i+1, len(filteredProvinsi))
+		reporter.Log("✅ Provinsi %s selesai (%d/%d)", prov.Nama, i+1, len(filteredProvinsi))
 	}
 
 	// Save final result
@@ -396,24 +530,195 @@ func (s *Scraper) ScrapeAll() error {
 		return nil
 	default:
 		s.saveToFile(allData, finalFile)
-		fmt.Println("✅ Selesai!")
-		fmt.Printf("   📁 File checkpoint: %s\n", checkpointFile)
-		fmt.Printf("   📁 File temp: %s\n", tempFile)
-		fmt.Printf("   📁 File final: %s\n", finalFile)
+		s.state.mu.Lock()
+		s.state.finalFile = finalFile
+		s.state.mu.Unlock()
+		reporter.Log("✅ Selesai!")
+		reporter.Log("   📁 File checkpoint: %s", checkpointFile)
+		reporter.Log("   📁 File temp: %s", tempFile)
+		reporter.Log("   📁 File final: %s", finalFile)
 
-		// Remove checkpoint
+		// Remove checkpoint and journal now that everything is captured in
+		// the final file
 		if err := os.Remove(checkpointFile); err == nil {
-			fmt.Printf("🗑️ Checkpoint dihapus: %s\n", checkpointFile)
+			reporter.Log("🗑️ Checkpoint dihapus: %s", checkpointFile)
+		}
+		jrnl.Close()
+		if err := os.Remove(journalFile); err == nil {
+			reporter.Log("🗑️ Journal dihapus: %s", journalFile)
+		}
+		if err := os.Remove(cacheFile); err == nil {
+			reporter.Log("🗑️ Response cache dihapus: %s", cacheFile)
 		}
 	}
 
 	return nil
 }
 
+// LoadLatestFinal finds and decodes the most recent wilayah_final_*.json in
+// the scraper's output directory. It returns an empty WilayahData if none
+// exists yet.
+func (s *Scraper) LoadLatestFinal() (*WilayahData, error) {
+	files, err := os.ReadDir(s.config.OutputDir)
+	if err != nil {
+		return &WilayahData{Pro: []Provinsi{}}, nil
+	}
+
+	var latest string
+	for _, file := range files {
+		name := file.Name()
+		if !file.IsDir() && strings.HasPrefix(name, "wilayah_final_") && strings.HasSuffix(name, ".json") {
+			if name > latest {
+				latest = name
+			}
+		}
+	}
+	if latest == "" {
+		return &WilayahData{Pro: []Provinsi{}}, nil
+	}
+
+	path := filepath.Join(s.config.OutputDir, latest)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var data WilayahData
+	if err := json.NewDecoder(file).Decode(&data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// hashIDNamePairs fingerprints an id->nama map as a short hex digest that's
+// stable regardless of map iteration order, so it can be compared across
+// runs to tell whether an upstream list actually changed.
+func hashIDNamePairs(data map[string]string) string {
+	pairs := make([]string, 0, len(data))
+	for id, nama := range data {
+		pairs = append(pairs, id+":"+nama)
+	}
+	sort.Strings(pairs)
+	sum := sha256.Sum256([]byte(strings.Join(pairs, "|")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// ScrapeIncremental re-fetches the list_pro/list_kab/list_kec heads against
+// baseline and only descends into a kabupaten's full kec/desa subtree when
+// its kecamatan id/nama set no longer matches the hash stored on the
+// baseline kabupaten — unchanged kabupaten are copied straight from baseline
+// instead of being re-walked, even inside a province that has other
+// kabupaten that did change. It writes a fresh wilayah_final_*.json and
+// returns the resulting data so the caller can diff it against baseline.
+func (s *Scraper) ScrapeIncremental(baseline *WilayahData) (*WilayahData, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.state.mu.Lock()
+	s.state.ctx = ctx
+	s.state.cancel = cancel
+	s.state.isRunning = true
+	s.state.mu.Unlock()
+
+	defer func() {
+		s.state.mu.Lock()
+		s.state.isRunning = false
+		s.state.mu.Unlock()
+	}()
+
+	os.MkdirAll(s.config.OutputDir, 0755)
+
+	reporter := progress.NewReporter(s.config.Progress)
+	reporter.Start()
+	defer reporter.Stop()
+	s.state.mu.Lock()
+	s.state.reporter = reporter
+	s.state.mu.Unlock()
+
+	baselineByID := make(map[string]Provinsi, len(baseline.Pro))
+	for _, p := range baseline.Pro {
+		baselineByID[p.ID] = p
+	}
+
+	provinsiData, err := s.provider.ListProvinsi(ctx, s.config.Year)
+	if err != nil {
+		return nil, fmt.Errorf("error getting provinces: %v", err)
+	}
+	provinsiData = s.filterProvinsiConfig(provinsiData)
+
+	allData := &WilayahData{}
+	reusedKab, rewalkedKab := 0, 0
+
+	for proID, proNama := range provinsiData {
+		select {
+		case <-ctx.Done():
+			return allData, nil
+		default:
+		}
+
+		kabupatenData, err := s.provider.ListKabupaten(ctx, s.config.Year, proID)
+		if err != nil {
+			metrics.ScrapeErrorsTotal.WithLabelValues("prov").Inc()
+			reporter.Log("❌ Error getting kabupaten for %s: %v", proNama, err)
+			continue
+		}
+
+		baseKabByID := make(map[string]Kabupaten)
+		if base, ok := baselineByID[proID]; ok {
+			for _, kab := range base.Kab {
+				baseKabByID[kab.ID] = kab
+			}
+		}
+
+		var toWalk []struct {
+			ID   string
+			Nama string
+		}
+		var kabupatenResults []Kabupaten
+
+		for kabID, kabNama := range kabupatenData {
+			baseKab, known := baseKabByID[kabID]
+			if known && baseKab.Nama == kabNama && baseKab.Hash != "" {
+				kecData, err := s.provider.ListKecamatan(ctx, s.config.Year, proID, kabID)
+				if err == nil && hashIDNamePairs(kecData) == baseKab.Hash {
+					kabupatenResults = append(kabupatenResults, baseKab)
+					reusedKab++
+					continue
+				}
+			}
+			toWalk = append(toWalk, struct {
+				ID   string
+				Nama string
+			}{ID: kabID, Nama: kabNama})
+		}
+
+		if len(toWalk) > 0 {
+			walked := s.processKabupatenParallel(ctx, toWalk, proID, proNama, nil, nil, reporter)
+			kabupatenResults = append(kabupatenResults, walked...)
+			rewalkedKab += len(toWalk)
+		}
+
+		allData.Pro = append(allData.Pro, Provinsi{ID: proID, Nama: proNama, Kab: kabupatenResults})
+	}
+
+	reporter.Log("🔁 Incremental: %d kabupaten tidak berubah, %d kabupaten diproses ulang", reusedKab, rewalkedKab)
+
+	dateStr := time.Now().Format("20060102")
+	finalFile := filepath.Join(s.config.OutputDir, fmt.Sprintf("wilayah_final_%s.json", dateStr))
+	if err := s.saveToFile(allData, finalFile); err != nil {
+		return allData, fmt.Errorf("error saving final file: %v", err)
+	}
+	s.state.mu.Lock()
+	s.state.finalFile = finalFile
+	s.state.mu.Unlock()
+
+	return allData, nil
+}
+
 func (s *Scraper) processKabupatenParallel(ctx context.Context, kabupatenItems []struct {
 	ID   string
 	Nama string
-}, provID, provNama string) []Kabupaten {
+}, provID, provNama string, journalState *journalState, jrnl *journal, reporter *progress.Reporter) []Kabupaten {
 	jobs := make(chan struct {
 		ID   string
 		Nama string
@@ -427,7 +732,15 @@ func (s *Scraper) processKabupatenParallel(ctx context.Context, kabupatenItems [
 		go func() {
 			defer wg.Done()
 			for kab := range jobs {
-				result := s.processKabupaten(ctx, kab.ID, kab.Nama, provID, provNama)
+				if journalState != nil {
+					if done, ok := journalState.completedKab[provID+"/"+kab.ID]; ok {
+						reporter.Log("    ⏭️ Kabupaten %s sudah selesai (journal), dilewati", kab.Nama)
+						results <- done
+						continue
+					}
+				}
+
+				result := s.processKabupaten(ctx, kab.ID, kab.Nama, provID, provNama, journalState, jrnl, reporter)
 				if result != nil {
 					results <- *result
 				}
@@ -462,14 +775,19 @@ func (s *Scraper) processKabupatenParallel(ctx context.Context, kabupatenItems [
 	return kabupatenResults
 }
 
-func (s *Scraper) processKabupaten(ctx context.Context, kabID, kabNama, provID, provNama string) *Kabupaten {
+func (s *Scraper) processKabupaten(ctx context.Context, kabID, kabNama, provID, provNama string, journalState *journalState, jrnl *journal, reporter *progress.Reporter) *Kabupaten {
 	select {
 	case <-ctx.Done():
 		return nil
 	default:
 	}
 
-	fmt.Printf("    🧵 Thread memproses kabupaten: %s\n", kabNama)
+	reporter.Log("    🧵 Thread memproses kabupaten: %s", kabNama)
+
+	metrics.WorkersActive.Inc()
+	reporter.KabupatenStarted()
+	defer metrics.WorkersActive.Dec()
+	defer reporter.KabupatenFinished()
 
 	kab := &Kabupaten{
 		ID:   kabID,
@@ -477,38 +795,67 @@ func (s *Scraper) processKabupaten(ctx context.Context, kabID, kabNama, provID,
 		Kec:  []Kecamatan{},
 	}
 
+	// Kecamatan already completed for this kabupaten in a previous run
+	// (recorded in the journal before the process was killed) are reused
+	// instead of re-fetched.
+	var doneKec map[string]Kecamatan
+	if journalState != nil {
+		doneKec = journalState.completedKec[provID+"/"+kabID]
+		for _, kec := range doneKec {
+			kab.Kec = append(kab.Kec, kec)
+		}
+	}
+
 	// Get kecamatan
-	kecamatanData, err := s.getJSON("list_kec", map[string]interface{}{"thn": s.config.Year, "pro": provID, "kab": kabID})
+	kecamatanData, err := s.provider.ListKecamatan(ctx, s.config.Year, provID, kabID)
 	if err != nil {
-		fmt.Printf("❌ Error getting kecamatan for %s: %v\n", kabNama, err)
+		metrics.ScrapeErrorsTotal.WithLabelValues("kab").Inc()
+		reporter.Log("❌ Error getting kecamatan for %s: %v", kabNama, err)
 		return nil
 	}
+	kab.Hash = hashIDNamePairs(kecamatanData)
 
 	// Process kecamatan
 	for kecID, kecNama := range kecamatanData {
+		if _, already := doneKec[kecID]; already {
+			continue
+		}
+
 		select {
 		case <-ctx.Done():
 			return kab
 		default:
 		}
 
-		kec := s.processKecamatan(ctx, kecID, kecNama.(string), provID, kabID)
+		kec := s.processKecamatan(ctx, kecID, kecNama, provID, kabID, reporter)
 		if kec != nil {
 			kab.Kec = append(kab.Kec, *kec)
+			reporter.KecamatanDone()
+			reporter.DesaAdded(len(kec.Des))
+			metrics.ItemsTotal.WithLabelValues("kec").Inc()
+			metrics.ItemsTotal.WithLabelValues("des").Add(float64(len(kec.Des)))
+			if jrnl != nil {
+				jrnl.appendKec(provID, kabID, *kec)
+			}
 		}
 	}
 
+	if jrnl != nil {
+		jrnl.appendKab(provID, *kab)
+	}
+
+	metrics.ItemsTotal.WithLabelValues("kab").Inc()
 	return kab
 }
 
-func (s *Scraper) processKecamatan(ctx context.Context, kecID, kecNama, provID, kabID string) *Kecamatan {
+func (s *Scraper) processKecamatan(ctx context.Context, kecID, kecNama, provID, kabID string, reporter *progress.Reporter) *Kecamatan {
 	select {
 	case <-ctx.Done():
 		return nil
 	default:
 	}
 
-	fmt.Printf("      🧵 Thread memproses kecamatan: %s\n", kecNama)
+	reporter.Log("      🧵 Thread memproses kecamatan: %s", kecNama)
 
 	kec := &Kecamatan{
 		ID:   kecID,
@@ -517,9 +864,10 @@ func (s *Scraper) processKecamatan(ctx context.Context, kecID, kecNama, provID,
 	}
 
 	// Get desa
-	desaData, err := s.getJSON("list_des", map[string]interface{}{"thn": s.config.Year, "pro": provID, "kab": kabID, "kec": kecID})
+	desaData, err := s.provider.ListDesa(ctx, s.config.Year, provID, kabID, kecID)
 	if err != nil {
-		fmt.Printf("❌ Error getting desa for %s: %v\n", kecNama, err)
+		metrics.ScrapeErrorsTotal.WithLabelValues("kec").Inc()
+		reporter.Log("❌ Error getting desa for %s: %v", kecNama, err)
 		return nil
 	}
 
@@ -533,7 +881,7 @@ func (s *Scraper) processKecamatan(ctx context.Context, kecID, kecNama, provID,
 
 		kec.Des = append(kec.Des, Desa{
 			ID:   desID,
-			Nama: desNama.(string),
+			Nama: desNama,
 		})
 	}
 
@@ -547,6 +895,14 @@ func (s *Scraper) IsRunning() bool {
 	return s.state.isRunning
 }
 
+// FinalFilePath returns the path of the JSON file written by the most
+// recently completed ScrapeAll run, or "" if none has completed yet.
+func (s *Scraper) FinalFilePath() string {
+	s.state.mu.RLock()
+	defer s.state.mu.RUnlock()
+	return s.state.finalFile
+}
+
 // Stop stops the scraper
 func (s *Scraper) Stop() {
 	s.state.mu.Lock()
@@ -557,11 +913,19 @@ func (s *Scraper) Stop() {
 	}
 }
 
-// GetProgress returns current scraping progress
+// GetProgress returns current scraping progress. While a scrape is running
+// it's backed by the same progress.Reporter the terminal bar renders from,
+// so the API and the CLI never disagree about how far along a run is.
 func (s *Scraper) GetProgress() map[string]interface{} {
 	s.state.mu.RLock()
 	defer s.state.mu.RUnlock()
 
+	if s.state.isRunning && s.state.reporter != nil {
+		snapshot := s.state.reporter.Snapshot()
+		snapshot["running"] = true
+		return snapshot
+	}
+
 	if s.state.currentData == nil {
 		return map[string]interface{}{
 			"provinces": 0,
@@ -592,6 +956,36 @@ func (s *Scraper) GetProgress() map[string]interface{} {
 	}
 }
 
+// GetProgressOpenMetrics renders the same counters as GetProgress in
+// OpenMetrics text exposition format, so a Prometheus scrape config can point
+// directly at the progress endpoint instead of only at /metrics.
+func (s *Scraper) GetProgressOpenMetrics() string {
+	return renderOpenMetrics(s.GetProgress())
+}
+
+func renderOpenMetrics(snapshot map[string]interface{}) string {
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		metric := "wilayah_progress_" + name
+		value := snapshot[name]
+		if running, ok := value.(bool); ok {
+			value = 0
+			if running {
+				value = 1
+			}
+		}
+		fmt.Fprintf(&b, "# TYPE %s gauge\n%s %v\n", metric, metric, value)
+	}
+	b.WriteString("# EOF\n")
+	return b.String()
+}
+
 // ShowCheckpointInfo shows information about existing checkpoints
 func (s *Scraper) ShowCheckpointInfo() {
 	checkpointDir := filepath.Join(s.config.OutputDir, "checkpoints")
@@ -716,6 +1110,33 @@ func ShowHelp() {
 	fmt.Println("   go run main.go scrape [threads]     - Mulai/lanjutkan scraping (default 4 threads)")
 	fmt.Println("   go run main.go scrape info          - Lihat info checkpoint")
 	fmt.Println("   go run main.go scrape clean [days]  - Hapus checkpoint lama (default 7 hari)")
+	fmt.Println("   go run main.go scrape diff <old> <new> - Bandingkan dua snapshot (lihat DIFF)")
+	fmt.Println("   go run main.go scrape watch         - Pantau --seed-file atau output.dir, scrape incremental")
+	fmt.Println("                                          otomatis saat baseline baru muncul (daemon, Ctrl+C berhenti)")
+	fmt.Println()
+	fmt.Println("🐢 POLITENESS FLAGS (ditempatkan sebelum [threads]):")
+	fmt.Println("   --config PATH        - Path ke file config YAML/TOML (default wilayah.yml, opsional)")
+	fmt.Println("   --profile NAME       - Pakai profiles.<name> dari --config, override rps/retries/backoff/year/format/only/exclude")
+	fmt.Println("   --rps N              - Batas request/detik bersama semua worker (default dari config, fallback 5)")
+	fmt.Println("   --max-retries N      - Jumlah percobaan ulang saat 429/5xx/network error (default dari config, fallback 3)")
+	fmt.Println("   --backoff-base DUR   - Delay dasar exponential backoff, mis. 500ms (default dari config, fallback 500ms)")
+	fmt.Println("   --format FMT,...     - Format file akhir, boleh lebih dari satu dipisah koma: json, ndjson, csv, sql, sqlite (default dari config, fallback json)")
+	fmt.Println("   --metrics-addr ADDR  - Jalankan server metrics Prometheus, mis. :9090 (opt-in)")
+	fmt.Println("   --incremental        - Lewati kabupaten yang hash daftar kecamatannya tidak berubah")
+	fmt.Println("   --year Y1,Y2,...     - Scrape beberapa tahun sekaligus, override sources di wilayah.yml")
+	fmt.Println("   --provider KIND      - Sumber data: sipedas (default, API live), seed (snapshot offline via --seed-file), merge (sipedas direkonsiliasi dengan --seed-file)")
+	fmt.Println("   --seed-file PATH     - File wilayah_final_*.json yang mendasari --provider=seed atau --provider=merge")
+	fmt.Println("   --progress           - Tampilkan satu baris live progress bar (provinsi/kab/kec/desa)")
+	fmt.Println("   --no-progress        - Paksa log satu baris per event (default)")
+	fmt.Println("   --silent             - Jangan cetak progress sama sekali")
+	fmt.Println()
+	fmt.Println("🔍 DIFF:")
+	fmt.Println("   go run main.go diff <old.json> <new.json>")
+	fmt.Println("                                        - Bandingkan dua snapshot, tulis wilayah_diff_YYYYMMDD.json")
+	fmt.Println()
+	fmt.Println("🔄 KONVERSI FORMAT:")
+	fmt.Println("   go run main.go convert <input.json> [--format FMT] [output]")
+	fmt.Println("                                        - Konversi wilayah_final_*.json ke format lain")
 	fmt.Println()
 	fmt.Println("ℹ️ BANTUAN:")
 	fmt.Println("   go run main.go help                 - Tampilkan bantuan ini")
@@ -731,8 +1152,15 @@ func ShowHelp() {
 	fmt.Println("🛑 STOP AMAN:")
 	fmt.Println("   Ctrl+C                              - Hentikan dengan checkpoint")
 	fmt.Println()
+	fmt.Println("⚙️ CONFIG:")
+	fmt.Println("   wilayah.yml                          - sources, workers, rate_limit, retries,")
+	fmt.Println("                                          output.formats/dir, checkpoint.keep_days, http.timeout")
+	fmt.Println("                                          (lihat wilayah.example.yml). Flag CLI selalu menimpa nilai di sini.")
+	fmt.Println()
 	fmt.Println("📁 FILE OUTPUT:")
-	fmt.Println("   scraper/output/checkpoints/         - Folder checkpoint")
+	fmt.Println("   scraper/output/checkpoints/          - Folder checkpoint")
+	fmt.Println("   scraper/output/checkpoints/journal_*.ndjson - Progress kab/kec granular (resume otomatis)")
+	fmt.Println("   scraper/output/checkpoints/cache_*.json     - Response cache (hindari fetch ulang saat resume)")
 	fmt.Println("   scraper/output/temp_wilayah_*.json  - File temporary")
 	fmt.Println("   scraper/output/wilayah_final_*.json - Hasil akhir")
 }
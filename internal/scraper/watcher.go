@@ -0,0 +1,72 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	fsnotify "gopkg.in/fsnotify.v1"
+
+	"wilayah-api/internal/logging"
+)
+
+// WatchSeed blocks, watching for a new baseline snapshot to show up on disk:
+// either writes/creates to the exact file at path, or, if path is empty, any
+// wilayah_final_*.json dropped into outputDir. Each time the watched
+// baseline changes, onChange is called with the path that changed so the
+// caller can kick off a fresh incremental scrape against it. WatchSeed
+// returns when ctx is canceled or the watcher hits a fatal error.
+func WatchSeed(ctx context.Context, outputDir, path string, logger logging.Logger, onChange func(path string)) error {
+	watchDir := outputDir
+	if path != "" {
+		watchDir = filepath.Dir(path)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(watchDir); err != nil {
+		return fmt.Errorf("watcher: watching %s: %w", watchDir, err)
+	}
+
+	logger.Info("watching for new baseline", "dir", watchDir)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+				continue
+			}
+			if path != "" {
+				if event.Name != path {
+					continue
+				}
+			} else if !isFinalSnapshot(event.Name) {
+				continue
+			}
+			logger.Info("new baseline detected", "path", event.Name)
+			onChange(event.Name)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error("watcher error", "error", err)
+		}
+	}
+}
+
+func isFinalSnapshot(name string) bool {
+	base := filepath.Base(name)
+	return strings.HasPrefix(base, "wilayah_final_") && strings.HasSuffix(base, ".json")
+}
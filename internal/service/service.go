@@ -0,0 +1,210 @@
+// Package service holds the store-backed request handling shared by the
+// HTTP and NATS transports: resolving a single code, batch/keyed lookups,
+// and listing provinsi/kabupaten. It depends only on internal/store, so
+// both main's Fiber handlers and its NATS subscribers can call the same
+// Service without either transport importing the other.
+package service
+
+import "wilayah-api/internal/store"
+
+// Ref is an ancestor reference (province/kabupaten/kecamatan) embedded in
+// an Info result, e.g. a kabupaten's Info includes its parent provinsi Ref.
+type Ref struct {
+	ID   string `json:"id"`
+	Nama string `json:"nama"`
+}
+
+// Map is a JSON object shaped like fiber.Map, used for Info so callers don't
+// have to import Fiber just to read a service result.
+type Map = map[string]interface{}
+
+// BatchItem is one requested code's resolution, code kept alongside so a
+// caller can match replies back up to an ordered request list.
+type BatchItem struct {
+	Code  string `json:"code"`
+	Info  Map    `json:"info,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// LookupItem is one requested code's resolution in a code-keyed lookup.
+type LookupItem struct {
+	Info  Map    `json:"info,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Service is the transport-agnostic core behind /info, /info/batch,
+// /lookup, /provinsi, and /kabupaten: every Fiber handler and NATS
+// subscriber for those routes calls through a shared *Service instead of
+// re-implementing the store walk.
+type Service struct {
+	store store.Store
+}
+
+// New builds a Service over store.
+func New(s store.Store) *Service {
+	return &Service{store: s}
+}
+
+// Info resolves a single BPS code (2/4/7/10 digits), the service-layer core
+// of GET /info/{code}. A non-empty errMsg means the lookup failed; status
+// is the HTTP status that error would map to.
+func (s *Service) Info(code string) (result Map, status int, errMsg string) {
+	switch len(code) {
+	case 2, 4, 7, 10:
+	default:
+		return nil, 400, "Invalid code length. Use 2 digits for province, 4 for kabupaten, 7 for kecamatan, or 10 for desa"
+	}
+
+	entry, ok := s.store.ByCode(code)
+	if !ok {
+		return nil, 404, s.codeNotFoundError(code)
+	}
+
+	switch entry.Level {
+	case "provinsi":
+		return Map{
+			"type":     "provinsi",
+			"id":       entry.Provinsi.ID,
+			"nama":     entry.Provinsi.Nama,
+			"children": len(entry.Provinsi.Kab),
+		}, 200, ""
+
+	case "kabupaten":
+		return Map{
+			"type":     "kabupaten",
+			"id":       entry.Kabupaten.ID,
+			"nama":     entry.Kabupaten.Nama,
+			"provinsi": Ref{ID: entry.Provinsi.ID, Nama: entry.Provinsi.Nama},
+			"children": len(entry.Kabupaten.Kec),
+		}, 200, ""
+
+	case "kecamatan":
+		return Map{
+			"type":      "kecamatan",
+			"id":        entry.Kecamatan.ID,
+			"nama":      entry.Kecamatan.Nama,
+			"kabupaten": Ref{ID: entry.Kabupaten.ID, Nama: entry.Kabupaten.Nama},
+			"provinsi":  Ref{ID: entry.Provinsi.ID, Nama: entry.Provinsi.Nama},
+			"children":  len(entry.Kecamatan.Des),
+		}, 200, ""
+
+	default: // "desa"
+		return Map{
+			"type":      "desa",
+			"id":        entry.Desa.ID,
+			"nama":      entry.Desa.Nama,
+			"kecamatan": Ref{ID: entry.Kecamatan.ID, Nama: entry.Kecamatan.Nama},
+			"kabupaten": Ref{ID: entry.Kabupaten.ID, Nama: entry.Kabupaten.Nama},
+			"provinsi":  Ref{ID: entry.Provinsi.ID, Nama: entry.Provinsi.Nama},
+		}, 200, ""
+	}
+}
+
+// codeNotFoundError maps a code's length to which level of the hierarchy
+// failed to resolve, so a 404 still tells the caller what's missing even
+// though store only reports a single miss rather than a walk that stops at
+// a specific level.
+func (s *Service) codeNotFoundError(code string) string {
+	switch len(code) {
+	case 2:
+		return "Province not found"
+	case 4:
+		if _, ok := s.store.ByCode(code[:2]); !ok {
+			return "Province not found"
+		}
+		return "Kabupaten/Kota not found"
+	case 7:
+		if _, ok := s.store.ByCode(code[:2]); !ok {
+			return "Province not found"
+		}
+		if _, ok := s.store.ByCode(code[:4]); !ok {
+			return "Kabupaten/Kota not found"
+		}
+		return "Kecamatan not found"
+	default: // 10
+		if _, ok := s.store.ByCode(code[:2]); !ok {
+			return "Province not found"
+		}
+		if _, ok := s.store.ByCode(code[:4]); !ok {
+			return "Kabupaten/Kota not found"
+		}
+		if _, ok := s.store.ByCode(code[:7]); !ok {
+			return "Kecamatan not found"
+		}
+		return "Desa/Kelurahan not found"
+	}
+}
+
+// batchInfoMaxCodes caps how many codes BatchInfo/Lookup accept in one
+// call, keeping a single abusive payload from doing unbounded work.
+const batchInfoMaxCodes = 1000
+
+// BatchInfo resolves codes in order, the service-layer core of
+// POST /info/batch. A code that doesn't resolve gets an Error instead of
+// failing the whole batch; codes beyond batchInfoMaxCodes are dropped.
+func (s *Service) BatchInfo(codes []string) []BatchItem {
+	if len(codes) > batchInfoMaxCodes {
+		codes = codes[:batchInfoMaxCodes]
+	}
+	items := make([]BatchItem, len(codes))
+	for i, code := range codes {
+		result, _, errMsg := s.Info(code)
+		if errMsg != "" {
+			items[i] = BatchItem{Code: code, Error: errMsg}
+			continue
+		}
+		items[i] = BatchItem{Code: code, Info: result}
+	}
+	return items
+}
+
+// Lookup resolves codes keyed by code, deduping repeats, the service-layer
+// core of POST /lookup.
+func (s *Service) Lookup(codes []string) map[string]LookupItem {
+	if len(codes) > batchInfoMaxCodes {
+		codes = codes[:batchInfoMaxCodes]
+	}
+	result := make(map[string]LookupItem, len(codes))
+	for _, code := range codes {
+		if _, done := result[code]; done {
+			continue
+		}
+		info, _, errMsg := s.Info(code)
+		if errMsg != "" {
+			result[code] = LookupItem{Error: errMsg}
+			continue
+		}
+		result[code] = LookupItem{Info: info}
+	}
+	return result
+}
+
+// Provinsi lists every province, the service-layer core of GET /provinsi
+// and wilayah.provinsi.list.
+func (s *Service) Provinsi() []Ref {
+	entries := s.store.PrefixSearch("provinsi", "", provinsiListLimit)
+	out := make([]Ref, len(entries))
+	for i, e := range entries {
+		out[i] = Ref{ID: e.Provinsi.ID, Nama: e.Provinsi.Nama}
+	}
+	return out
+}
+
+// provinsiListLimit is comfortably above Indonesia's ~38 provinces so
+// Provinsi's PrefixSearch("", ...) call never truncates the listing.
+const provinsiListLimit = 1000
+
+// KabupatenByProvince lists proID's kabupaten/kota, the service-layer core
+// of GET /kabupaten?pro=... and wilayah.kabupaten.byProvince/list. ok is
+// false when proID itself doesn't resolve.
+func (s *Service) KabupatenByProvince(proID string) (kab []Ref, ok bool) {
+	children, ok := s.store.Children(proID)
+	if !ok {
+		return nil, false
+	}
+	out := make([]Ref, len(children))
+	for i, entry := range children {
+		out[i] = Ref{ID: entry.Kabupaten.ID, Nama: entry.Kabupaten.Nama}
+	}
+	return out, true
+}
@@ -0,0 +1,247 @@
+// Package store indexes a loaded wilayah dataset for O(1) BPS-code lookups,
+// immediate-children listings, and trie-backed prefix search, replacing the
+// linear find*/slice-walk scans the API handlers used to run on every
+// request.
+package store
+
+import (
+	"sort"
+	"strings"
+)
+
+// Desa/Kecamatan/Kabupaten/Provinsi mirror the API's wilayah tree, trimmed
+// to the ID/Nama/children a lookup or listing needs.
+type Desa struct {
+	ID, Nama string
+}
+
+type Kecamatan struct {
+	ID, Nama string
+	Des      []Desa
+}
+
+type Kabupaten struct {
+	ID, Nama string
+	Kec      []Kecamatan
+}
+
+type Provinsi struct {
+	ID, Nama string
+	Kab      []Kabupaten
+}
+
+// Entry is one ByCode/Children/PrefixSearch hit: the matched node's level,
+// plus whichever ancestors resolve for it (nil above the dataset root), so
+// e.g. a desa hit's kecamatan/kabupaten/provinsi are available without
+// re-walking the tree.
+type Entry struct {
+	Level     string // "provinsi" | "kabupaten" | "kecamatan" | "desa"
+	Provinsi  *Provinsi
+	Kabupaten *Kabupaten
+	Kecamatan *Kecamatan
+	Desa      *Desa
+}
+
+// Store answers O(1) code lookups, immediate-children listings, and
+// normalized-name prefix search over a loaded wilayah dataset.
+type Store interface {
+	// ByCode resolves a full BPS code (2/4/7/10 digits) to its Entry.
+	ByCode(code string) (Entry, bool)
+	// Children returns code's node's immediate children one level down
+	// (kabupaten for a provinsi code, kecamatan for a kabupaten code, desa
+	// for a kecamatan code). A desa code has no children and returns
+	// (nil, true). The bool is false only when code itself doesn't resolve.
+	Children(code string) ([]Entry, bool)
+	// PrefixSearch returns up to limit Entries at level whose normalized
+	// Nama starts with the normalized prefix, trie-ordered.
+	PrefixSearch(level, prefix string, limit int) []Entry
+}
+
+// Normalize lowercases s and strips everything but a-z0-9, the same folding
+// New/PrefixSearch apply to every Nama so lookups are case- and
+// punctuation-insensitive.
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// trieNode is one normalized-name trie node; leaves holds the index (into
+// the owning level's entries slice) of every name that ends exactly here.
+type trieNode struct {
+	children map[byte]*trieNode
+	leaves   []int
+}
+
+func newTrieNode() *trieNode { return &trieNode{children: make(map[byte]*trieNode)} }
+
+func (t *trieNode) insert(name string, idx int) {
+	node := t
+	for i := 0; i < len(name); i++ {
+		b := name[i]
+		child, ok := node.children[b]
+		if !ok {
+			child = newTrieNode()
+			node.children[b] = child
+		}
+		node = child
+	}
+	node.leaves = append(node.leaves, idx)
+}
+
+func (t *trieNode) walk(prefix string) *trieNode {
+	node := t
+	for i := 0; i < len(prefix); i++ {
+		child, ok := node.children[prefix[i]]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+	return node
+}
+
+// collect walks the subtree rooted at t in byte order, appending leaf
+// indices to out until limit is reached.
+func (t *trieNode) collect(limit int, out *[]int) {
+	if len(*out) >= limit {
+		return
+	}
+	*out = append(*out, t.leaves...)
+	keys := make([]byte, 0, len(t.children))
+	for b := range t.children {
+		keys = append(keys, b)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, b := range keys {
+		if len(*out) >= limit {
+			return
+		}
+		t.children[b].collect(limit, out)
+	}
+}
+
+// levelIndex is one administrative level's entries plus its prefix trie.
+type levelIndex struct {
+	entries []Entry
+	trie    *trieNode
+}
+
+type store struct {
+	byCode map[string]Entry
+	levels map[string]*levelIndex
+}
+
+// New builds a Store from pro, walking the tree once to populate byCode
+// (keyed by the full concatenated BPS code) and a normalized-name trie per
+// level.
+func New(pro []Provinsi) Store {
+	s := &store{
+		byCode: make(map[string]Entry),
+		levels: map[string]*levelIndex{
+			"provinsi":  {trie: newTrieNode()},
+			"kabupaten": {trie: newTrieNode()},
+			"kecamatan": {trie: newTrieNode()},
+			"desa":      {trie: newTrieNode()},
+		},
+	}
+
+	for pi := range pro {
+		p := &pro[pi]
+		pEntry := Entry{Level: "provinsi", Provinsi: p}
+		s.byCode[p.ID] = pEntry
+		s.index("provinsi", p.Nama, pEntry)
+
+		for ki := range p.Kab {
+			k := &p.Kab[ki]
+			kEntry := Entry{Level: "kabupaten", Provinsi: p, Kabupaten: k}
+			kCode := p.ID + k.ID
+			s.byCode[kCode] = kEntry
+			s.index("kabupaten", k.Nama, kEntry)
+
+			for ci := range k.Kec {
+				kec := &k.Kec[ci]
+				kecEntry := Entry{Level: "kecamatan", Provinsi: p, Kabupaten: k, Kecamatan: kec}
+				kecCode := kCode + kec.ID
+				s.byCode[kecCode] = kecEntry
+				s.index("kecamatan", kec.Nama, kecEntry)
+
+				for di := range kec.Des {
+					d := &kec.Des[di]
+					dEntry := Entry{Level: "desa", Provinsi: p, Kabupaten: k, Kecamatan: kec, Desa: d}
+					s.byCode[kecCode+d.ID] = dEntry
+					s.index("desa", d.Nama, dEntry)
+				}
+			}
+		}
+	}
+
+	return s
+}
+
+func (s *store) index(level, nama string, entry Entry) {
+	li := s.levels[level]
+	idx := len(li.entries)
+	li.entries = append(li.entries, entry)
+	li.trie.insert(Normalize(nama), idx)
+}
+
+func (s *store) ByCode(code string) (Entry, bool) {
+	e, ok := s.byCode[code]
+	return e, ok
+}
+
+func (s *store) Children(code string) ([]Entry, bool) {
+	entry, ok := s.byCode[code]
+	if !ok {
+		return nil, false
+	}
+	switch entry.Level {
+	case "provinsi":
+		out := make([]Entry, len(entry.Provinsi.Kab))
+		for i := range entry.Provinsi.Kab {
+			k := &entry.Provinsi.Kab[i]
+			out[i] = Entry{Level: "kabupaten", Provinsi: entry.Provinsi, Kabupaten: k}
+		}
+		return out, true
+	case "kabupaten":
+		out := make([]Entry, len(entry.Kabupaten.Kec))
+		for i := range entry.Kabupaten.Kec {
+			kec := &entry.Kabupaten.Kec[i]
+			out[i] = Entry{Level: "kecamatan", Provinsi: entry.Provinsi, Kabupaten: entry.Kabupaten, Kecamatan: kec}
+		}
+		return out, true
+	case "kecamatan":
+		out := make([]Entry, len(entry.Kecamatan.Des))
+		for i := range entry.Kecamatan.Des {
+			d := &entry.Kecamatan.Des[i]
+			out[i] = Entry{Level: "desa", Provinsi: entry.Provinsi, Kabupaten: entry.Kabupaten, Kecamatan: entry.Kecamatan, Desa: d}
+		}
+		return out, true
+	default: // desa has no children
+		return nil, true
+	}
+}
+
+func (s *store) PrefixSearch(level, prefix string, limit int) []Entry {
+	li, ok := s.levels[level]
+	if !ok || limit <= 0 {
+		return nil
+	}
+	node := li.trie.walk(Normalize(prefix))
+	if node == nil {
+		return nil
+	}
+	idxs := make([]int, 0, limit)
+	node.collect(limit, &idxs)
+	out := make([]Entry, len(idxs))
+	for i, idx := range idxs {
+		out[i] = li.entries[idx]
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+)
+
+// buildBenchData fabricates a dataset roughly the shape of the real one
+// (38 provinsi x ~15 kabupaten x ~12 kecamatan x ~20 desa) so the benchmarks
+// below reflect the linear-scan-vs-map tradeoff at realistic scale.
+func buildBenchData() []Provinsi {
+	pro := make([]Provinsi, 38)
+	for pi := range pro {
+		kab := make([]Kabupaten, 15)
+		for ki := range kab {
+			kec := make([]Kecamatan, 12)
+			for ci := range kec {
+				des := make([]Desa, 20)
+				for di := range des {
+					des[di] = Desa{ID: fmt.Sprintf("%03d", di), Nama: fmt.Sprintf("DESA %d-%d-%d-%d", pi, ki, ci, di)}
+				}
+				kec[ci] = Kecamatan{ID: fmt.Sprintf("%03d", ci), Nama: fmt.Sprintf("KEC %d-%d-%d", pi, ki, ci), Des: des}
+			}
+			kab[ki] = Kabupaten{ID: fmt.Sprintf("%02d", ki), Nama: fmt.Sprintf("KAB %d-%d", pi, ki), Kec: kec}
+		}
+		pro[pi] = Provinsi{ID: fmt.Sprintf("%02d", pi), Nama: fmt.Sprintf("PROVINSI %d", pi), Kab: kab}
+	}
+	return pro
+}
+
+// findDesaLinear mirrors the pre-store getWilayahInfo code path: a linear
+// scan at every level to resolve a 10-digit desa code.
+func findDesaLinear(pro []Provinsi, code string) (Desa, bool) {
+	proID, kabID, kecID, desID := code[:2], code[2:4], code[4:7], code[7:]
+	for _, p := range pro {
+		if p.ID != proID {
+			continue
+		}
+		for _, k := range p.Kab {
+			if k.ID != kabID {
+				continue
+			}
+			for _, kec := range k.Kec {
+				if kec.ID != kecID {
+					continue
+				}
+				for _, d := range kec.Des {
+					if d.ID == desID {
+						return d, true
+					}
+				}
+			}
+		}
+	}
+	return Desa{}, false
+}
+
+// BenchmarkFindDesaLinear measures the linear-scan baseline for resolving a
+// worst-case (last-in-tree) desa code.
+func BenchmarkFindDesaLinear(b *testing.B) {
+	pro := buildBenchData()
+	code := "37" + "14" + "011" + "019" // last provinsi/kabupaten/kecamatan/desa
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := findDesaLinear(pro, code); !ok {
+			b.Fatal("code not found")
+		}
+	}
+}
+
+// BenchmarkByCode measures the same lookup through Store.ByCode.
+func BenchmarkByCode(b *testing.B) {
+	pro := buildBenchData()
+	s := New(pro)
+	code := "37" + "14" + "011" + "019"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := s.ByCode(code); !ok {
+			b.Fatal("code not found")
+		}
+	}
+}
+
+func TestByCodeResolvesAncestry(t *testing.T) {
+	pro := buildBenchData()
+	s := New(pro)
+
+	entry, ok := s.ByCode("0000011019")
+	if !ok {
+		t.Fatal("expected code to resolve")
+	}
+	if entry.Level != "desa" || entry.Desa == nil || entry.Kecamatan == nil || entry.Kabupaten == nil || entry.Provinsi == nil {
+		t.Fatalf("expected full ancestry, got %+v", entry)
+	}
+	if entry.Provinsi.ID != "00" || entry.Kabupaten.ID != "00" || entry.Kecamatan.ID != "011" || entry.Desa.ID != "019" {
+		t.Fatalf("unexpected ancestry IDs: %+v", entry)
+	}
+
+	if _, ok := s.ByCode("99999999"); ok {
+		t.Fatal("expected unknown code to miss")
+	}
+}
+
+func TestChildrenAndPrefixSearch(t *testing.T) {
+	pro := buildBenchData()
+	s := New(pro)
+
+	children, ok := s.Children("00")
+	if !ok || len(children) != 15 {
+		t.Fatalf("expected 15 kabupaten children, got %d (ok=%v)", len(children), ok)
+	}
+
+	none, ok := s.Children("0000011019")
+	if !ok || none != nil {
+		t.Fatalf("expected desa to have no children, got %v (ok=%v)", none, ok)
+	}
+
+	hits := s.PrefixSearch("provinsi", "provinsi 3", 100)
+	if len(hits) == 0 {
+		t.Fatal("expected at least one provinsi 3x prefix match")
+	}
+	for _, h := range hits {
+		if h.Provinsi == nil {
+			t.Fatalf("prefix hit missing Provinsi: %+v", h)
+		}
+	}
+}
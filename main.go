@@ -1,25 +1,52 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"hash/fnv"
 	"log"
+	"math"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
 	"unicode"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/swagger"
+	"github.com/nats-io/nats.go"
+	"github.com/valyala/fasthttp"
 
 	_ "wilayah-api/docs"
+	"wilayah-api/internal/config"
+	"wilayah-api/internal/diff"
+	"wilayah-api/internal/exporter"
+	"wilayah-api/internal/fetcher"
+	"wilayah-api/internal/logging"
+	"wilayah-api/internal/metrics"
+	"wilayah-api/internal/progress"
+	"wilayah-api/internal/provider"
+	"wilayah-api/internal/query"
 	"wilayah-api/internal/scraper"
+	"wilayah-api/internal/scraper/rules"
+	"wilayah-api/internal/service"
+	"wilayah-api/internal/store"
 )
 
 // @title           Indonesian Region API
@@ -70,11 +97,165 @@ type WilayahData struct {
 	Pro []Provinsi `json:"pro"`
 }
 
-// Global variable to store the loaded data
-var wilayahData *WilayahData
-var globalScraper *scraper.Scraper
+// Global variable to store the loaded data. It's an atomic.Pointer rather
+// than a plain *WilayahData so POST /admin/reload can rotate it out from
+// under in-flight requests without a lock: every reader takes one Load()
+// and works from that snapshot, even if a reload swaps it out mid-request.
+var wilayahData atomic.Pointer[WilayahData]
+var globalJobManager *scraper.JobManager
 var apiKey string
 
+// datasetMeta describes the currently loaded wilayahData snapshot: Hash
+// identifies its content, GeneratedAt is when that content was produced
+// (parsed from its source filename, falling back to the file's mtime), and
+// LoadedAt is when this process last read it in. GET /api/v1/version
+// reports all three; the ETag/Last-Modified middleware uses Hash/GeneratedAt.
+type datasetMeta struct {
+	Hash        string
+	GeneratedAt time.Time
+	LoadedAt    time.Time
+}
+
+var currentDatasetMeta atomic.Pointer[datasetMeta]
+
+// datasetFilenameTimestamp extracts the YYYYMMDD[_HHMMSS] timestamp the
+// scraper embeds in wilayah_final_*.json / temp_wilayah_*.json filenames.
+var datasetFilenameTimestamp = regexp.MustCompile(`(\d{8}(?:_\d{6})?)\.json$`)
+
+// datasetGeneratedAt determines when filename's content was produced: it
+// prefers the timestamp embedded in the filename (how the scraper names its
+// output), falling back to the file's mtime if the name doesn't match.
+func datasetGeneratedAt(filename string) time.Time {
+	if m := datasetFilenameTimestamp.FindStringSubmatch(filename); m != nil {
+		layout := "20060102"
+		if strings.Contains(m[1], "_") {
+			layout = "20060102_150405"
+		}
+		if t, err := time.ParseInLocation(layout, m[1], time.Local); err == nil {
+			return t
+		}
+	}
+	if info, err := os.Stat(filename); err == nil {
+		return info.ModTime()
+	}
+	return time.Now()
+}
+
+// datasetHash is a SHA-256 over data's canonical JSON encoding, hex-encoded,
+// so GET /api/v1/version and the ETag middleware can tell two loads of the
+// dataset apart (or confirm they're identical) without comparing the whole
+// tree.
+func datasetHash(data *WilayahData) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setWilayahData atomically installs data as the current dataset, computing
+// its hash/generatedAt/loadedAt together so a concurrent GET /api/v1/version
+// or cache-validation request never observes a hash that doesn't match the
+// data it's paired with.
+func setWilayahData(data *WilayahData, filename string) error {
+	hash, err := datasetHash(data)
+	if err != nil {
+		return fmt.Errorf("error hashing dataset: %v", err)
+	}
+	wilayahData.Store(data)
+	currentDatasetMeta.Store(&datasetMeta{
+		Hash:        hash,
+		GeneratedAt: datasetGeneratedAt(filename),
+		LoadedAt:    time.Now(),
+	})
+	return nil
+}
+
+// searchMaxTimeout caps the ?timeout= query param accepted by deadline-aware
+// endpoints (search, desa), regardless of what a client asks for. Overridden
+// by the SEARCH_MAX_TIMEOUT env var (e.g. "500ms") in runAPI.
+var searchMaxTimeout = 5 * time.Second
+
+// searchCheckInterval is how often the ctx-aware add-loops below check
+// ctx.Err(), in iterations. Checking every call would dominate the loop's
+// own cost; checking too rarely delays reacting to a cancelled request.
+const searchCheckInterval = 1024
+
+// requestDeadline builds a context.Context bounded by the request's
+// ?timeout= query param (capped at searchMaxTimeout) so a slow client or a
+// pathological query can't pin a worker goroutine past that ceiling.
+func requestDeadline(c *fiber.Ctx) (context.Context, context.CancelFunc) {
+	timeout := searchMaxTimeout
+	if raw := strings.TrimSpace(c.Query("timeout")); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 && d < timeout {
+			timeout = d
+		}
+	}
+	return context.WithTimeout(c.UserContext(), timeout)
+}
+
+// queryParams flattens a request's query string into a plain map, so it can
+// be handed to query.Parse without internal/query depending on fiber.
+func queryParams(c *fiber.Ctx) map[string]string {
+	params := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		params[string(key)] = string(value)
+	})
+	return params
+}
+
+// datasetETag builds the dataset-hash-plus-path ETag cacheMiddleware sets:
+// the dataset hash (so a reload always invalidates every cached response)
+// joined to an FNV-1a hash of the request's path+query (so two different
+// requests against the same dataset don't collide on one ETag).
+func datasetETag(datasetHash, path string) string {
+	h := fnv.New32a()
+	h.Write([]byte(path))
+	return fmt.Sprintf(`"%s-%x"`, datasetHash, h.Sum32())
+}
+
+// cacheMiddleware answers conditional GETs against the current dataset
+// snapshot: it sets ETag/Last-Modified on every GET response, and short-
+// circuits with 304 Not Modified when the request's If-None-Match or
+// If-Modified-Since already matches it. The dataset only changes on
+// POST /api/v1/admin/reload, so between reloads every GET for the same
+// path+query is safe to cache this way.
+func cacheMiddleware(c *fiber.Ctx) error {
+	if c.Method() != fiber.MethodGet {
+		return c.Next()
+	}
+	meta := currentDatasetMeta.Load()
+	if meta == nil {
+		return c.Next()
+	}
+
+	etag := datasetETag(meta.Hash, c.OriginalURL())
+	lastModified := meta.GeneratedAt.UTC()
+
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+
+	if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" && inm == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	if ims := c.Get(fiber.HeaderIfModifiedSince); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.After(t) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+	}
+	return c.Next()
+}
+
+// listCacheControl marks a listing endpoint's response as cacheable for a
+// full day: the dataset only changes on an explicit reload, so there's
+// nothing to gain from a shorter TTL, and cacheMiddleware's ETag already
+// lets a client revalidate for free if it wants to.
+func listCacheControl(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400, immutable")
+	return c.Next()
+}
+
 // API key middleware for scraper control endpoints
 func apiKeyMiddleware(c *fiber.Ctx) error {
 	// Skip middleware if API key is not set
@@ -134,16 +315,40 @@ type DesaResponse struct {
 	Nama string `json:"nama" example:"GANTARANG"`
 }
 
+// DesaListResponse wraps a deadline-bounded /desa listing. It is only used
+// when no pro/kab/kec/desa filter is supplied, since that walks every desa
+// in the dataset (~80k rows) instead of one kecamatan's worth.
+type DesaListResponse struct {
+	Count   int            `json:"count" example:"83931"`
+	Results []DesaResponse `json:"results"`
+	// Partial is set when the request's deadline ran out before every
+	// kecamatan finished being walked; Results then only holds what was
+	// collected so far.
+	Partial bool `json:"partial,omitempty" example:"false"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error" example:"Province not found"`
 }
 
+// ListEnvelope wraps a /provinsi, /kabupaten, /kecamatan, or /desa listing
+// once a request uses any query.Filter key (nama_like, sort, page, ...):
+// Data holds the filtered/sorted/paginated page as that endpoint's usual
+// []XResponse, Meta the total/page/per_page summary. A request using none
+// of that vocabulary still gets the endpoint's original plain-array
+// response, so existing clients aren't broken.
+type ListEnvelope struct {
+	Data interface{} `json:"data"`
+	Meta query.Meta  `json:"meta"`
+}
+
 type HealthResponse struct {
 	Status    string `json:"status" example:"OK"`
 	Message   string `json:"message" example:"Indonesian Region API is running"`
 	DataCount struct {
 		Provinces int `json:"provinces" example:"38"`
 	} `json:"data_count"`
+	NatsConnected bool `json:"nats_connected" example:"false"`
 }
 
 type StatsResponse struct {
@@ -171,6 +376,10 @@ type SearchResponse struct {
 	Limit   int          `json:"limit,omitempty" example:"50"`
 	Results []string     `json:"results" example:"BENTENG, BENTENG, KEPULAUAN SELAYAR, SULAWESI SELATAN"`
 	Items   []SearchItem `json:"items,omitempty"`
+	// Partial is set when the request's deadline (see ?timeout=) ran out
+	// before every level finished being scanned; Count/Results/Items then
+	// only reflect whatever was collected so far.
+	Partial bool `json:"partial,omitempty" example:"false"`
 }
 
 // Structured search item
@@ -183,24 +392,183 @@ type SearchItem struct {
 		Des string `json:"des,omitempty" example:"001"`
 	} `json:"ids"`
 	Label string `json:"label" example:"BENTENG, BENTENG, KEPULAUAN SELAYAR, SULAWESI SELATAN"`
+	// Distance is the Levenshtein edit distance to the query, set only on
+	// fuzzy matches (0 for prefix/substring matches) so clients can re-rank.
+	Distance int `json:"distance,omitempty" example:"1"`
+	// Explain is only populated when the request set ?explain=1.
+	Explain *SearchExplain `json:"explain,omitempty"`
+}
+
+// Suggest response model
+type SuggestResponse struct {
+	Query string        `json:"query" example:"benteng"`
+	Count int           `json:"count" example:"3"`
+	Items []SuggestItem `json:"items"`
+}
+
+// Structured suggest candidate, DaData-style: a hierarchical breadcrumb plus
+// the concatenated pro+kab+kec+des code.
+type SuggestItem struct {
+	Type string `json:"type" example:"desa"`
+	Code string `json:"code" example:"7302010001"`
+	IDs  struct {
+		Pro string `json:"pro" example:"73"`
+		Kab string `json:"kab,omitempty" example:"02"`
+		Kec string `json:"kec,omitempty" example:"010"`
+		Des string `json:"des,omitempty" example:"001"`
+	} `json:"ids"`
+	// Breadcrumb goes leaf to root: [desa, kecamatan, kabupaten, provinsi],
+	// trimmed to however many levels the candidate actually has.
+	Breadcrumb []string `json:"breadcrumb" example:"BENTENG,GANTARANG,BULUKUMBA,SULAWESI SELATAN"`
+	Label      string   `json:"label" example:"BENTENG, GANTARANG, BULUKUMBA, SULAWESI SELATAN"`
+	// Score is the hybrid ranking score (0..1) runSuggest computed for this
+	// candidate: prefix-match bonus, token overlap with the query, and
+	// normalized Damerau-Levenshtein similarity against Nama.
+	Score float64 `json:"score" example:"0.92"`
+}
+
+// ParseRequest is the payload for POST /parse: one raw, free-text address.
+type ParseRequest struct {
+	Address string `json:"address" example:"Jl. Merdeka No. 10, Kel. Benteng, Kec. Benteng, Kab. Kepulauan Selayar, Sulsel"`
+}
+
+// ParseResponse is the response for POST /parse.
+type ParseResponse struct {
+	Query      string           `json:"query" example:"Jl. Merdeka No. 10, Kel. Benteng, Kec. Benteng, Kab. Kepulauan Selayar, Sulsel"`
+	Confidence float64          `json:"confidence" example:"0.92"`
+	Candidates []ParseCandidate `json:"candidates"`
 }
 
+// ParseCandidate is one hierarchy match produced by parseAddress, DaData
+// /clean-style: the resolved IDs, a normalized label, and a 0..1 confidence
+// score reflecting how well the input tokens matched at each level (a level
+// that couldn't be resolved simply stops the walk and costs that level's
+// share of the score).
+type ParseCandidate struct {
+	IDs struct {
+		Pro string `json:"pro,omitempty" example:"73"`
+		Kab string `json:"kab,omitempty" example:"02"`
+		Kec string `json:"kec,omitempty" example:"010"`
+		Des string `json:"des,omitempty" example:"001"`
+	} `json:"ids"`
+	Label      string  `json:"label" example:"BENTENG, BENTENG, KEPULAUAN SELAYAR, SULAWESI SELATAN"`
+	Confidence float64 `json:"confidence" example:"0.92"`
+}
+
+// batchInfoMaxCodes caps how many codes POST /info/batch accepts in one
+// request, keeping a single abusive payload from doing unbounded work.
+const batchInfoMaxCodes = 1000
+
+// BatchInfoRequest is the payload for POST /info/batch.
+type BatchInfoRequest struct {
+	Codes []string `json:"codes" example:"73,7302,7302010,7302010001"`
+}
+
+// BatchInfoResponse is the response for POST /info/batch.
+type BatchInfoResponse struct {
+	Count int             `json:"count" example:"4"`
+	Items []BatchInfoItem `json:"items"`
+}
+
+// BatchInfoItem mirrors one code from the request, in the same order: Info
+// holds the resolveWilayahInfo-shaped result on success, Error holds the
+// failure reason so one bad code doesn't fail the whole batch.
+type BatchInfoItem struct {
+	Code  string      `json:"code" example:"7302010001"`
+	Info  interface{} `json:"info,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// LookupRequest is the payload for POST /lookup.
+type LookupRequest struct {
+	Codes []string `json:"codes" example:"73,7302,7302010,7302010001"`
+}
+
+// LookupResult is one requested code's resolution in a POST /lookup
+// response: Info holds the resolveWilayahInfo-shaped result on success,
+// Error holds the failure reason so one bad code doesn't fail the batch.
+type LookupResult struct {
+	Info  interface{} `json:"info,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// LookupResponse maps every requested code to its LookupResult, following
+// the by-codes map pattern (cf. GetProvincesByCodes/GetWardsByCodes) rather
+// than /info/batch's ordered-list shape.
+type LookupResponse map[string]LookupResult
+
+// KabupatenBatchRequest is the payload for POST /kabupaten/batch.
+type KabupatenBatchRequest struct {
+	Pro []string `json:"pro" example:"73,11"`
+}
+
+// KabupatenBatchItem is one requested province's children in a
+// POST /kabupaten/batch response: Items holds its kabupaten/kota on
+// success, Error holds the failure reason.
+type KabupatenBatchItem struct {
+	Items []KabupatenResponse `json:"items,omitempty"`
+	Error string              `json:"error,omitempty"`
+}
+
+// KabupatenBatchResponse maps each requested province ID to its
+// KabupatenBatchItem, so a client can populate many provinces' regency
+// dropdowns in a single call.
+type KabupatenBatchResponse map[string]KabupatenBatchItem
+
+// KecamatanBatchRequest is the payload for POST /kecamatan/batch. Kab holds
+// combined pro+kab codes (4 digits), the same convention as GET
+// /kecamatan?kec=.
+type KecamatanBatchRequest struct {
+	Kab []string `json:"kab" example:"7302,1101"`
+}
+
+// KecamatanBatchItem is one requested kabupaten's children in a
+// POST /kecamatan/batch response.
+type KecamatanBatchItem struct {
+	Items []KecamatanResponse `json:"items,omitempty"`
+	Error string              `json:"error,omitempty"`
+}
+
+// KecamatanBatchResponse maps each requested combined pro+kab code to its
+// KecamatanBatchItem.
+type KecamatanBatchResponse map[string]KecamatanBatchItem
+
+// DesaBatchRequest is the payload for POST /desa/batch. Kec holds combined
+// pro+kab+kec codes (7 digits), the same convention as GET /desa?desa=.
+type DesaBatchRequest struct {
+	Kec []string `json:"kec" example:"7302010,1101020"`
+}
+
+// DesaBatchItem is one requested kecamatan's children in a
+// POST /desa/batch response.
+type DesaBatchItem struct {
+	Items []DesaResponse `json:"items,omitempty"`
+	Error string         `json:"error,omitempty"`
+}
+
+// DesaBatchResponse maps each requested combined pro+kab+kec code to its
+// DesaBatchItem.
+type DesaBatchResponse map[string]DesaBatchItem
+
 // In-memory search index
 type (
 	desaIndex struct {
 		Pro, Kab, Kec, Des string
 		NameNorm           string
+		ParentNorms        []string // kecamatan, kabupaten, provinsi, in that order
 		Label              string
 	}
 	kecIndex struct {
 		Pro, Kab, Kec string
 		NameNorm      string
+		ParentNorms   []string // kabupaten, provinsi, in that order
 		Label         string
 	}
 	kabIndex struct {
-		Pro, Kab string
-		NameNorm string
-		Label    string
+		Pro, Kab    string
+		NameNorm    string
+		ParentNorms []string // provinsi
+		Label       string
 	}
 	provIndex struct {
 		Pro      string
@@ -212,10 +580,306 @@ type (
 		Kecamatan []kecIndex
 		Kabupaten []kabIndex
 		Provinsi  []provIndex
+
+		// Tries index each level's NameNorm so a leaf-name prefix lookup
+		// (the common case for /suggest) costs O(len(q)) instead of a scan
+		// over every entry at that level.
+		DesaTrie      *trieNode
+		KecamatanTrie *trieNode
+		KabupatenTrie *trieNode
+		ProvinsiTrie  *trieNode
+
+		// BK-trees index each level's NameNorm for fuzzy search, so a bounded
+		// edit-distance query prunes most of the tree via the triangle
+		// inequality instead of computing Levenshtein against every entry.
+		DesaBK      *bkNode
+		KecamatanBK *bkNode
+		KabupatenBK *bkNode
+		ProvinsiBK  *bkNode
+
+		// Trigram indexes narrow a prefix/contains search down to the rows
+		// that actually share shingles with the query, so runSearch never
+		// has to scan every entry at a level just to rank prefix > contains.
+		DesaTrigram      *trigramIndex
+		KecamatanTrigram *trigramIndex
+		KabupatenTrigram *trigramIndex
+		ProvinsiTrigram  *trigramIndex
 	}
 )
 
-var searchIndex *SearchIndex
+// searchIndex is an atomic.Pointer rather than a plain *SearchIndex for the
+// same reason wilayahData is (see its comment): POST /api/v1/admin/reload
+// rebuilds it concurrently with in-flight search/suggest/parse requests, so
+// every reader takes one Load() into a local variable and works from that
+// snapshot rather than dereferencing the shared global mid-rebuild.
+var searchIndex atomic.Pointer[SearchIndex]
+
+// ensureSearchIndex returns the current search index, building it on first
+// use if runAPI's startup build hasn't happened yet (e.g. in tests that
+// call runSearch/runSuggest/parseAddress directly).
+func ensureSearchIndex() *SearchIndex {
+	if idx := searchIndex.Load(); idx != nil {
+		return idx
+	}
+	buildSearchIndex()
+	return searchIndex.Load()
+}
+
+// trieNode is a prefix tree keyed on normalizeName output. Every node along
+// an inserted string's path accumulates the index of that string in its
+// level's slice, so prefixSearch(q) just walks len(q) nodes and returns the
+// accumulated list — no scanning.
+type trieNode struct {
+	children map[rune]*trieNode
+	entries  []int
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+func (t *trieNode) insert(s string, idx int) {
+	node := t
+	node.entries = append(node.entries, idx)
+	for _, r := range s {
+		child, ok := node.children[r]
+		if !ok {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+		node.entries = append(node.entries, idx)
+	}
+}
+
+// prefixSearch returns the indices of every string inserted with prefix as a
+// prefix, or nil if nothing matches.
+func (t *trieNode) prefixSearch(prefix string) []int {
+	node := t
+	for _, r := range prefix {
+		child, ok := node.children[r]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+	return node.entries
+}
+
+// bkNode is one node of a BK-tree (Burkhard-Keller tree): children are keyed
+// by their edit distance from this node's string. Because edit distance is a
+// metric, the triangle inequality lets search prune any child whose edge
+// label falls outside [dist-maxD, dist+maxD], so a bounded fuzzy query costs
+// far less than scanning every indexed string.
+type bkNode struct {
+	idx      int
+	nameNorm string
+	children map[int]*bkNode
+}
+
+func newBKNode(idx int, nameNorm string) *bkNode {
+	return &bkNode{idx: idx, nameNorm: nameNorm, children: make(map[int]*bkNode)}
+}
+
+// bkInsert adds (idx, nameNorm) to the tree rooted at *root, creating the
+// root itself on the first call.
+func bkInsert(root **bkNode, idx int, nameNorm string) {
+	if *root == nil {
+		*root = newBKNode(idx, nameNorm)
+		return
+	}
+	(*root).insert(idx, nameNorm)
+}
+
+func (n *bkNode) insert(idx int, nameNorm string) {
+	dist := levenshtein(n.nameNorm, nameNorm)
+	if dist == 0 {
+		return // exact duplicate name, already reachable from this node
+	}
+	if child, ok := n.children[dist]; ok {
+		child.insert(idx, nameNorm)
+		return
+	}
+	n.children[dist] = newBKNode(idx, nameNorm)
+}
+
+// bkMatch is one hit returned by bkNode.search: the matched entry's index
+// into its level's slice, and its edit distance from the query.
+type bkMatch struct {
+	idx      int
+	distance int
+}
+
+// search appends every string within maxD edit distance of query to out,
+// pruning subtrees whose edge label can't possibly fall within maxD given
+// the triangle inequality.
+func (n *bkNode) search(query string, maxD int, out *[]bkMatch) {
+	dist := levenshtein(n.nameNorm, query)
+	if dist <= maxD {
+		*out = append(*out, bkMatch{idx: n.idx, distance: dist})
+	}
+	for edge, child := range n.children {
+		if edge >= dist-maxD && edge <= dist+maxD {
+			child.search(query, maxD, out)
+		}
+	}
+}
+
+// trigramsOf splits s into overlapping 3-rune shingles, padding the front with
+// "$$" so a query/name shorter than 3 runes still yields at least one
+// shingle and so the leading shingles double as a "starts with" signal.
+func trigramsOf(s string) []string {
+	runes := []rune("$$" + s)
+	if len(runes) < 3 {
+		return []string{string(runes)}
+	}
+	grams := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+3]))
+	}
+	return grams
+}
+
+// uniqueTrigramsOf is trigramsOf deduped, since a name with a repeated
+// pattern (e.g. "CICI...") would otherwise list the same shingle twice and
+// throw off both posting-list size and Jaccard math.
+func uniqueTrigramsOf(s string) []string {
+	grams := trigramsOf(s)
+	seen := make(map[string]bool, len(grams))
+	out := grams[:0]
+	for _, g := range grams {
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+		out = append(out, g)
+	}
+	return out
+}
+
+// trigramIndex is a compact inverted index from 3-rune shingle to the sorted
+// row IDs (indices into the owning level's slice) of every NameNorm
+// containing that shingle. Posting lists are delta-encoded as varints so the
+// ~80k-desa index doesn't cost much more than a plain []uint32 per shingle.
+type trigramIndex struct {
+	postings map[string][]byte
+}
+
+// trigramBuilder accumulates postings during buildSearchIndex before
+// encodeDeltaVarint compacts each one into a trigramIndex.
+type trigramBuilder struct {
+	postings map[string][]uint32
+}
+
+func newTrigramBuilder() *trigramBuilder {
+	return &trigramBuilder{postings: make(map[string][]uint32)}
+}
+
+// add indexes name under rowID. Entries are built in increasing rowID order,
+// so each posting list comes out already sorted for delta encoding.
+func (b *trigramBuilder) add(name string, rowID uint32) {
+	for _, g := range uniqueTrigramsOf(name) {
+		b.postings[g] = append(b.postings[g], rowID)
+	}
+}
+
+func (b *trigramBuilder) build() *trigramIndex {
+	idx := &trigramIndex{postings: make(map[string][]byte, len(b.postings))}
+	for gram, ids := range b.postings {
+		idx.postings[gram] = encodeDeltaVarint(ids)
+	}
+	return idx
+}
+
+func encodeDeltaVarint(ids []uint32) []byte {
+	buf := make([]byte, 0, len(ids)*2)
+	var prev uint32
+	for _, id := range ids {
+		buf = binary.AppendUvarint(buf, uint64(id-prev))
+		prev = id
+	}
+	return buf
+}
+
+func decodeDeltaVarint(buf []byte) []uint32 {
+	ids := make([]uint32, 0, len(buf))
+	var prev uint32
+	for len(buf) > 0 {
+		delta, n := binary.Uvarint(buf)
+		if n <= 0 {
+			break
+		}
+		buf = buf[n:]
+		prev += uint32(delta)
+		ids = append(ids, prev)
+	}
+	return ids
+}
+
+// candidateScores walks queryGrams once, decoding each shingle's posting
+// list and tallying how many shingles each row shares with the query. That
+// per-row count is the Jaccard intersection size: searchCandidates below
+// turns it into a similarity score used to keep the scan/rank pass below
+// from ever touching every entry at a level.
+func (t *trigramIndex) candidateScores(queryGrams []string) map[uint32]int {
+	scores := make(map[uint32]int)
+	for _, g := range queryGrams {
+		buf, ok := t.postings[g]
+		if !ok {
+			continue
+		}
+		for _, rowID := range decodeDeltaVarint(buf) {
+			scores[rowID]++
+		}
+	}
+	return scores
+}
+
+// searchTrigramJaccardThreshold is the minimum Jaccard similarity (shared
+// shingles / union of shingles) a row needs to be considered a candidate at
+// all. It's deliberately low: this is only a recall-oriented pre-filter that
+// replaces a full scan over a level, not the final prefix/contains/fuzzy
+// classification, which still runs an exact check on whatever it lets
+// through.
+const searchTrigramJaccardThreshold = 0.12
+
+// trigramCandidate is one row a trigram pre-filter decided is worth an exact
+// prefix/contains check, along with the Jaccard score that earned it a spot
+// (surfaced to the caller only for ?explain=1).
+type trigramCandidate struct {
+	RowID   uint32
+	Jaccard float64
+}
+
+// searchCandidates returns the rows worth exact-checking for q against an
+// index built over nameNorms, instead of every row at that level.
+func searchCandidates(idx *trigramIndex, q string, nameNormOf func(rowID uint32) string) []trigramCandidate {
+	queryGrams := uniqueTrigramsOf(q)
+	scores := idx.candidateScores(queryGrams)
+
+	candidates := make([]trigramCandidate, 0, len(scores))
+	for rowID, shared := range scores {
+		entryGrams := len(uniqueTrigramsOf(nameNormOf(rowID)))
+		union := len(queryGrams) + entryGrams - shared
+		if union <= 0 {
+			continue
+		}
+		if jaccard := float64(shared) / float64(union); jaccard >= searchTrigramJaccardThreshold {
+			candidates = append(candidates, trigramCandidate{RowID: rowID, Jaccard: jaccard})
+		}
+	}
+	return candidates
+}
+
+// SearchExplain is attached to a SearchItem when the request set
+// ?explain=1: it surfaces the score components runSearch used to classify
+// and rank that item, mainly for debugging relevance and tuning
+// searchTrigramJaccardThreshold.
+type SearchExplain struct {
+	MatchType string  `json:"match_type" example:"contains"`
+	Jaccard   float64 `json:"jaccard,omitempty" example:"0.42"`
+	Distance  int     `json:"distance,omitempty" example:"1"`
+}
 
 func normalizeName(s string) string {
 	if s == "" {
@@ -231,99 +895,542 @@ func normalizeName(s string) string {
 }
 
 func buildSearchIndex() {
-	idx := &SearchIndex{}
-	for _, p := range wilayahData.Pro {
+	idx := &SearchIndex{
+		ProvinsiTrie:  newTrieNode(),
+		KabupatenTrie: newTrieNode(),
+		KecamatanTrie: newTrieNode(),
+		DesaTrie:      newTrieNode(),
+	}
+	proTri := newTrigramBuilder()
+	kabTri := newTrigramBuilder()
+	kecTri := newTrigramBuilder()
+	desTri := newTrigramBuilder()
+
+	for _, p := range wilayahData.Load().Pro {
+		proNorm := normalizeName(p.Nama)
+
 		// Provinsi
 		idx.Provinsi = append(idx.Provinsi, provIndex{
 			Pro:      p.ID,
-			NameNorm: normalizeName(p.Nama),
+			NameNorm: proNorm,
 			Label:    p.Nama,
 		})
+		idx.ProvinsiTrie.insert(proNorm, len(idx.Provinsi)-1)
+		bkInsert(&idx.ProvinsiBK, len(idx.Provinsi)-1, proNorm)
+		proTri.add(proNorm, uint32(len(idx.Provinsi)-1))
+
 		for _, k := range p.Kab {
+			kabNorm := normalizeName(k.Nama)
+
 			// Kabupaten
 			idx.Kabupaten = append(idx.Kabupaten, kabIndex{
 				Pro: p.ID, Kab: k.ID,
-				NameNorm: normalizeName(k.Nama),
-				Label:    fmt.Sprintf("%s, %s", k.Nama, p.Nama),
+				NameNorm:    kabNorm,
+				ParentNorms: []string{proNorm},
+				Label:       fmt.Sprintf("%s, %s", k.Nama, p.Nama),
 			})
+			idx.KabupatenTrie.insert(kabNorm, len(idx.Kabupaten)-1)
+			bkInsert(&idx.KabupatenBK, len(idx.Kabupaten)-1, kabNorm)
+			kabTri.add(kabNorm, uint32(len(idx.Kabupaten)-1))
+
 			for _, kc := range k.Kec {
+				kecNorm := normalizeName(kc.Nama)
+
 				// Kecamatan
 				idx.Kecamatan = append(idx.Kecamatan, kecIndex{
 					Pro: p.ID, Kab: k.ID, Kec: kc.ID,
-					NameNorm: normalizeName(kc.Nama),
-					Label:    fmt.Sprintf("%s, %s, %s", kc.Nama, k.Nama, p.Nama),
+					NameNorm:    kecNorm,
+					ParentNorms: []string{kabNorm, proNorm},
+					Label:       fmt.Sprintf("%s, %s, %s", kc.Nama, k.Nama, p.Nama),
 				})
+				idx.KecamatanTrie.insert(kecNorm, len(idx.Kecamatan)-1)
+				bkInsert(&idx.KecamatanBK, len(idx.Kecamatan)-1, kecNorm)
+				kecTri.add(kecNorm, uint32(len(idx.Kecamatan)-1))
+
 				for _, d := range kc.Des {
+					desNorm := normalizeName(d.Nama)
+
 					// Desa
 					idx.Desa = append(idx.Desa, desaIndex{
 						Pro: p.ID, Kab: k.ID, Kec: kc.ID, Des: d.ID,
-						NameNorm: normalizeName(d.Nama),
-						Label:    fmt.Sprintf("%s, %s, %s, %s", d.Nama, kc.Nama, k.Nama, p.Nama),
+						NameNorm:    desNorm,
+						ParentNorms: []string{kecNorm, kabNorm, proNorm},
+						Label:       fmt.Sprintf("%s, %s, %s, %s", d.Nama, kc.Nama, k.Nama, p.Nama),
 					})
+					idx.DesaTrie.insert(desNorm, len(idx.Desa)-1)
+					bkInsert(&idx.DesaBK, len(idx.Desa)-1, desNorm)
+					desTri.add(desNorm, uint32(len(idx.Desa)-1))
 				}
 			}
 		}
 	}
-	searchIndex = idx
+
+	idx.ProvinsiTrigram = proTri.build()
+	idx.KabupatenTrigram = kabTri.build()
+	idx.KecamatanTrigram = kecTri.build()
+	idx.DesaTrigram = desTri.build()
+
+	searchIndex.Store(idx)
 }
 
-// Levenshtein distance (runes) for simple fuzzy matching
-func levenshtein(a, b string) int {
-	ar := []rune(a)
-	br := []rune(b)
-	n := len(ar)
-	m := len(br)
-	if n == 0 {
-		return m
-	}
-	if m == 0 {
-		return n
+// addressAliasesPath is a small JSON file of common Indonesian
+// province/region nicknames (e.g. "SULSEL" -> "SULAWESI SELATAN"), kept next
+// to scraper/output so it ships alongside the scraped data rather than
+// baked into the binary.
+const addressAliasesPath = "scraper/address_aliases.json"
+
+var addressAliases map[string]string
+
+// loadAddressAliases loads addressAliasesPath into addressAliases. A missing
+// or invalid file isn't fatal: parseAddress just falls back to matching
+// tokens against full region names directly.
+func loadAddressAliases() {
+	addressAliases = make(map[string]string)
+
+	data, err := os.ReadFile(addressAliasesPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: failed to read %s: %v", addressAliasesPath, err)
+		}
+		return
 	}
-	prev := make([]int, m+1)
-	curr := make([]int, m+1)
-	for j := 0; j <= m; j++ {
-		prev[j] = j
+	if err := json.Unmarshal(data, &addressAliases); err != nil {
+		log.Printf("Warning: failed to parse %s: %v", addressAliasesPath, err)
+		addressAliases = make(map[string]string)
 	}
-	for i := 1; i <= n; i++ {
-		curr[0] = i
-		for j := 1; j <= m; j++ {
-			cost := 0
-			if ar[i-1] != br[j-1] {
-				cost = 1
-			}
-			del := prev[j] + 1
-			ins := curr[j-1] + 1
-			sub := prev[j-1] + cost
-			// min
-			if del < ins {
-				if del < sub {
-					curr[j] = del
-				} else {
-					curr[j] = sub
-				}
-			} else {
-				if ins < sub {
-					curr[j] = ins
-				} else {
-					curr[j] = sub
-				}
-			}
+}
+
+// addressLevelPrefixes are stripped from a comma-separated address segment
+// before it's matched against the hierarchy. Longer forms come first so
+// "KECAMATAN" isn't cut short by the "KEC" entry.
+var addressLevelPrefixes = []string{
+	"KECAMATAN", "KELURAHAN", "KABUPATEN", "PROVINSI",
+	"KAB.", "KEC.", "KEL.", "PROV.",
+	"KAB", "KEC", "KEL", "PROV", "KOTA", "DESA",
+}
+
+func stripAddressPrefix(part string) string {
+	trimmed := strings.TrimSpace(part)
+	upper := strings.ToUpper(trimmed)
+	for _, p := range addressLevelPrefixes {
+		if strings.HasPrefix(upper, p) {
+			rest := strings.TrimSpace(trimmed[len(p):])
+			rest = strings.TrimPrefix(rest, ".")
+			return strings.TrimSpace(rest)
 		}
-		prev, curr = curr, prev
 	}
-	return prev[m]
+	return trimmed
 }
 
-// Scraper response models
-type ScraperStartResponse struct {
-	Message string `json:"message" example:"Scraper started successfully"`
-	Threads int    `json:"threads" example:"6"`
-	Status  string `json:"status" example:"running"`
+// parseMatchMinScore is the minimum tokenMatchScore a hierarchy entry needs
+// to be accepted as a level match; anything below this stops the top-down
+// walk rather than latching onto a bad guess.
+const parseMatchMinScore = 0.55
+
+// parseConfidenceThreshold is the confidence above which parseAddress
+// collapses to a single best candidate instead of returning alternatives.
+const parseConfidenceThreshold = 0.8
+
+// parseCandidateLimit caps how many alternative candidates parseAddress
+// returns when confidence is below parseConfidenceThreshold.
+const parseCandidateLimit = 3
+
+// tokenMatchScore scores how well one normalized address token matches one
+// normalized hierarchy name: 1 for an exact match, 0.85 for a substring
+// match either way, otherwise a Levenshtein-based score that degrades with
+// edit distance relative to the longer string.
+func tokenMatchScore(tokenNorm, candNorm string) float64 {
+	if tokenNorm == "" || candNorm == "" {
+		return 0
+	}
+	if tokenNorm == candNorm {
+		return 1
+	}
+	if strings.Contains(candNorm, tokenNorm) || strings.Contains(tokenNorm, candNorm) {
+		return 0.85
+	}
+	dist := levenshtein(tokenNorm, candNorm)
+	maxLen := len(tokenNorm)
+	if len(candNorm) > maxLen {
+		maxLen = len(candNorm)
+	}
+	score := 1 - float64(dist)/float64(maxLen)
+	if score < 0 {
+		score = 0
+	}
+	return score
 }
 
-type ScraperStopResponse struct {
-	Message string `json:"message" example:"Scraper stop signal sent"`
-	Status  string `json:"status" example:"stopping"`
+// bestTokenScore returns the best tokenMatchScore of candNorm against any of
+// the given address tokens.
+func bestTokenScore(tokens []string, candNorm string) float64 {
+	best := 0.0
+	for _, t := range tokens {
+		if s := tokenMatchScore(t, candNorm); s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+func bestKabupaten(idx *SearchIndex, tokens []string, proID string) (int, float64) {
+	bestIdx, bestScore := -1, 0.0
+	for i, e := range idx.Kabupaten {
+		if e.Pro != proID {
+			continue
+		}
+		if s := bestTokenScore(tokens, e.NameNorm); s > bestScore {
+			bestScore, bestIdx = s, i
+		}
+	}
+	if bestScore < parseMatchMinScore {
+		return -1, 0
+	}
+	return bestIdx, bestScore
+}
+
+func bestKecamatan(idx *SearchIndex, tokens []string, proID, kabID string) (int, float64) {
+	bestIdx, bestScore := -1, 0.0
+	for i, e := range idx.Kecamatan {
+		if e.Pro != proID || e.Kab != kabID {
+			continue
+		}
+		if s := bestTokenScore(tokens, e.NameNorm); s > bestScore {
+			bestScore, bestIdx = s, i
+		}
+	}
+	if bestScore < parseMatchMinScore {
+		return -1, 0
+	}
+	return bestIdx, bestScore
+}
+
+func bestDesa(idx *SearchIndex, tokens []string, proID, kabID, kecID string) (int, float64) {
+	bestIdx, bestScore := -1, 0.0
+	for i, e := range idx.Desa {
+		if e.Pro != proID || e.Kab != kabID || e.Kec != kecID {
+			continue
+		}
+		if s := bestTokenScore(tokens, e.NameNorm); s > bestScore {
+			bestScore, bestIdx = s, i
+		}
+	}
+	if bestScore < parseMatchMinScore {
+		return -1, 0
+	}
+	return bestIdx, bestScore
+}
+
+// parseAddress tokenizes a raw, comma-separated address, strips common
+// administrative prefixes (kel./kec./kab./kota/prov.), resolves aliases
+// (e.g. "SULSEL" -> "SULAWESI SELATAN"), then walks the hierarchy top-down
+// per candidate province: kabupaten, then kecamatan, then desa, each via
+// normalizeName first and the Levenshtein helper as a fallback. Candidates
+// are sorted by confidence (sum of matched-level scores over 4 levels); if
+// the best one clears parseConfidenceThreshold only it is returned, else up
+// to parseCandidateLimit alternatives are returned so the caller can
+// disambiguate.
+func parseAddress(address string) []ParseCandidate {
+	var tokens []string
+	for _, part := range strings.Split(address, ",") {
+		stripped := stripAddressPrefix(part)
+		if stripped == "" {
+			continue
+		}
+		norm := normalizeName(stripped)
+		if full, ok := addressAliases[norm]; ok {
+			norm = normalizeName(full)
+		}
+		if norm != "" {
+			tokens = append(tokens, norm)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	idx := ensureSearchIndex()
+
+	type proGuess struct {
+		idx   int
+		score float64
+	}
+	var proGuesses []proGuess
+	for i, e := range idx.Provinsi {
+		if s := bestTokenScore(tokens, e.NameNorm); s >= parseMatchMinScore {
+			proGuesses = append(proGuesses, proGuess{idx: i, score: s})
+		}
+	}
+	sort.Slice(proGuesses, func(i, j int) bool { return proGuesses[i].score > proGuesses[j].score })
+	if len(proGuesses) > parseCandidateLimit {
+		proGuesses = proGuesses[:parseCandidateLimit]
+	}
+
+	var candidates []ParseCandidate
+	for _, pg := range proGuesses {
+		pro := idx.Provinsi[pg.idx]
+		scores := []float64{pg.score}
+		label := pro.Label
+		var kabID, kecID, desID string
+
+		if kabIdx, kabScore := bestKabupaten(idx, tokens, pro.Pro); kabIdx >= 0 {
+			kab := idx.Kabupaten[kabIdx]
+			kabID, label = kab.Kab, kab.Label
+			scores = append(scores, kabScore)
+
+			if kecIdx, kecScore := bestKecamatan(idx, tokens, pro.Pro, kabID); kecIdx >= 0 {
+				kec := idx.Kecamatan[kecIdx]
+				kecID, label = kec.Kec, kec.Label
+				scores = append(scores, kecScore)
+
+				if desIdx, desScore := bestDesa(idx, tokens, pro.Pro, kabID, kecID); desIdx >= 0 {
+					des := idx.Desa[desIdx]
+					desID, label = des.Des, des.Label
+					scores = append(scores, desScore)
+				}
+			}
+		}
+
+		sum := 0.0
+		for _, s := range scores {
+			sum += s
+		}
+		confidence := math.Round(sum/4*100) / 100
+
+		cand := ParseCandidate{Label: label, Confidence: confidence}
+		cand.IDs.Pro, cand.IDs.Kab, cand.IDs.Kec, cand.IDs.Des = pro.Pro, kabID, kecID, desID
+		candidates = append(candidates, cand)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Confidence > candidates[j].Confidence })
+
+	if len(candidates) > 0 && candidates[0].Confidence >= parseConfidenceThreshold {
+		candidates = candidates[:1]
+	} else if len(candidates) > parseCandidateLimit {
+		candidates = candidates[:parseCandidateLimit]
+	}
+	return candidates
+}
+
+// Levenshtein distance (runes) for simple fuzzy matching
+func levenshtein(a, b string) int {
+	ar := []rune(a)
+	br := []rune(b)
+	n := len(ar)
+	m := len(br)
+	if n == 0 {
+		return m
+	}
+	if m == 0 {
+		return n
+	}
+	prev := make([]int, m+1)
+	curr := make([]int, m+1)
+	for j := 0; j <= m; j++ {
+		prev[j] = j
+	}
+	for i := 1; i <= n; i++ {
+		curr[0] = i
+		for j := 1; j <= m; j++ {
+			cost := 0
+			if ar[i-1] != br[j-1] {
+				cost = 1
+			}
+			del := prev[j] + 1
+			ins := curr[j-1] + 1
+			sub := prev[j-1] + cost
+			// min
+			if del < ins {
+				if del < sub {
+					curr[j] = del
+				} else {
+					curr[j] = sub
+				}
+			} else {
+				if ins < sub {
+					curr[j] = ins
+				} else {
+					curr[j] = sub
+				}
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[m]
+}
+
+// damerauLevenshtein is Levenshtein distance extended with adjacent
+// transpositions costing 1 (the "optimal string alignment" variant), so a
+// typo like "Bulukmuba" for "Bulukumba" scores closer than plain Levenshtein
+// would. Used by runSuggest's hybrid score instead of the plain levenshtein
+// helper above, which parseAddress/runSearch's fuzzy pass still use.
+func damerauLevenshtein(a, b string) int {
+	ar := []rune(a)
+	br := []rune(b)
+	n, m := len(ar), len(br)
+	if n == 0 {
+		return m
+	}
+	if m == 0 {
+		return n
+	}
+	d := make([][]int, n+1)
+	for i := range d {
+		d[i] = make([]int, m+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			del := d[i-1][j] + 1
+			ins := d[i][j-1] + 1
+			sub := d[i-1][j-1] + cost
+			best := del
+			if ins < best {
+				best = ins
+			}
+			if sub < best {
+				best = sub
+			}
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				if trans := d[i-2][j-2] + cost; trans < best {
+					best = trans
+				}
+			}
+			d[i][j] = best
+		}
+	}
+	return d[n][m]
+}
+
+// nameTokens splits a display name into its upper-cased word tokens, e.g.
+// "Kepulauan Selayar" -> ["KEPULAUAN", "SELAYAR"], for suggest's
+// token-overlap score. Unlike normalizeName it keeps words separate instead
+// of collapsing them into one run.
+func nameTokens(s string) []string {
+	var tokens []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			cur.WriteRune(unicode.ToUpper(r))
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// tokenOverlap is the Jaccard similarity between two token sets: shared
+// tokens over the union, 0 when either side is empty.
+func tokenOverlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	setA := make(map[string]bool, len(a))
+	for _, t := range a {
+		setA[t] = true
+	}
+	setB := make(map[string]bool, len(b))
+	for _, t := range b {
+		setB[t] = true
+	}
+	shared := 0
+	for t := range setA {
+		if setB[t] {
+			shared++
+		}
+	}
+	union := len(setA) + len(setB) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// suggestOwnName extracts a candidate's own display name from its
+// breadcrumb-style Label (the part before the first ", "), e.g.
+// "BENTENG, GANTARANG, BULUKUMBA, SULAWESI SELATAN" -> "BENTENG".
+func suggestOwnName(label string) string {
+	if i := strings.Index(label, ", "); i >= 0 {
+		return label[:i]
+	}
+	return label
+}
+
+// suggestPrefixBonus turns a candidate's match tier (see runSuggest) into the
+// prefix-match component of its hybrid score: a leaf-name prefix match is
+// worth the most, a parent-name prefix match less, a plain substring match
+// least.
+func suggestPrefixBonus(tier int) float64 {
+	switch tier {
+	case 0:
+		return 1.0
+	case 1:
+		return 0.6
+	default:
+		return 0.3
+	}
+}
+
+// suggestHybridScore combines suggestPrefixBonus, token overlap between the
+// query and the candidate's own name, and normalized Damerau-Levenshtein
+// similarity against nameNorm into a single 0..1 ranking score.
+func suggestHybridScore(nq string, nameNorm string, tier int, qTokens, nameToks []string) float64 {
+	overlap := tokenOverlap(qTokens, nameToks)
+
+	maxLen := len(nq)
+	if len(nameNorm) > maxLen {
+		maxLen = len(nameNorm)
+	}
+	similarity := 0.0
+	if maxLen > 0 {
+		similarity = 1 - float64(damerauLevenshtein(nq, nameNorm))/float64(maxLen)
+		if similarity < 0 {
+			similarity = 0
+		}
+	}
+
+	return 0.5*suggestPrefixBonus(tier) + 0.3*overlap + 0.2*similarity
+}
+
+// normalizeSuggestQuery prepares a /suggest query the same way parseAddress
+// prepares an address segment: strip a leading administrative prefix
+// (Kab./Kabupaten/Kec./...) and fold known Indonesian nickname variants
+// (e.g. "Jogja" -> "Daerah Istimewa Yogyakarta") via addressAliases, before
+// normalizing to the upper-case alnum-only form the search index is keyed
+// on.
+func normalizeSuggestQuery(q string) string {
+	norm := normalizeName(stripAddressPrefix(q))
+	if full, ok := addressAliases[norm]; ok {
+		norm = normalizeName(full)
+	}
+	return norm
+}
+
+// Scraper response models
+type ScraperStartResponse struct {
+	Message string `json:"message" example:"Scraper started successfully"`
+	Threads int    `json:"threads" example:"6"`
+	Status  string `json:"status" example:"running"`
+	JobID   string `json:"job_id" example:"job_1a2b3c4d5e6f7890"`
+}
+
+type ScraperStopResponse struct {
+	Message string `json:"message" example:"Scraper stop signal sent"`
+	Status  string `json:"status" example:"stopping"`
 }
 
 type ScraperStatusResponse struct {
@@ -345,6 +1452,48 @@ type ScraperInfoResponse struct {
 	Methods        interface{} `json:"methods"`
 }
 
+// ScraperJobRequest configures a job submitted via POST /scraper/jobs. Only
+// and KabOnly scope the scrape to a subtree (e.g. Only: ["73"] for one
+// provinsi, or KabOnly: ["7301"] for one kabupaten); leaving both empty walks
+// everything. CronExpr is accepted and stored for a future recurring-run
+// scheduler but is not executed yet.
+type ScraperJobRequest struct {
+	Workers  int      `json:"workers,omitempty" example:"4"`
+	Only     []string `json:"only,omitempty" example:"73"`
+	KabOnly  []string `json:"kab_only,omitempty" example:"7301"`
+	Exclude  []string `json:"exclude,omitempty"`
+	Priority int      `json:"priority,omitempty"`
+	CronExpr string   `json:"cron_expr,omitempty"`
+	RuleSet  string   `json:"rule_set,omitempty" example:"sipedas"`
+	DryRun   bool     `json:"dry_run,omitempty"`
+}
+
+// ScraperJobResponse mirrors scraper.JobRecord for swagger documentation.
+type ScraperJobResponse struct {
+	ID             string      `json:"id" example:"job_1a2b3c4d5e6f7890"`
+	Spec           interface{} `json:"spec"`
+	SubmittedAt    string      `json:"submitted_at" example:"2026-07-29T10:00:00Z"`
+	Status         string      `json:"status" example:"running"`
+	CheckpointPath string      `json:"checkpoint_path,omitempty"`
+	Error          string      `json:"error,omitempty"`
+}
+
+type ScraperJobStopResponse struct {
+	Message string `json:"message" example:"stop signal sent"`
+	JobID   string `json:"job_id" example:"job_1a2b3c4d5e6f7890"`
+}
+
+// RuleSetResponse mirrors rules.RuleSet for swagger documentation.
+type RuleSetResponse struct {
+	Name string      `json:"name" example:"sipedas"`
+	Root interface{} `json:"root"`
+}
+
+type RulesReloadResponse struct {
+	Message string `json:"message" example:"rule sets reloaded"`
+	Count   int    `json:"count" example:"1"`
+}
+
 // findLatestDataFile searches for the most recent wilayah data file
 func findLatestDataFile() (string, error) {
 	outputDir := "scraper/output"
@@ -447,43 +1596,79 @@ func loadWilayahData() error {
 	defer file.Close()
 
 	decoder := json.NewDecoder(file)
-	wilayahData = &WilayahData{}
-	if err := decoder.Decode(wilayahData); err != nil {
+	data := &WilayahData{}
+	if err := decoder.Decode(data); err != nil {
 		return fmt.Errorf("error decoding JSON from %s: %v", filename, err)
 	}
 
-	log.Printf("Successfully loaded %d provinces from %s", len(wilayahData.Pro), filename)
-	return nil
-}
+	log.Printf("Successfully loaded %d provinces from %s", len(data.Pro), filename)
 
-// Find province by ID
-func findProvinsi(proID string) *Provinsi {
-	for _, p := range wilayahData.Pro {
-		if p.ID == proID {
-			return &p
-		}
+	if err := setWilayahData(data, filename); err != nil {
+		return err
 	}
+	s := store.New(toStoreProvinsi(data.Pro))
+	wilayahStore.Store(&s)
+	wilayahService.Store(service.New(s))
 	return nil
 }
 
-// Find kabupaten by ID within a province
-func findKabupaten(provinsi *Provinsi, kabID string) *Kabupaten {
-	for _, k := range provinsi.Kab {
-		if k.ID == kabID {
-			return &k
-		}
+// reloadWilayahData re-reads the latest data file and rebuilds every
+// structure derived from it (store, service, search index), the core of
+// POST /api/v1/admin/reload. It reuses loadWilayahData's own file-finding
+// logic, so a reload always picks up whatever the scraper has most
+// recently produced rather than requiring the caller to name a file.
+func reloadWilayahData() error {
+	if err := loadWilayahData(); err != nil {
+		return err
 	}
+	buildSearchIndex()
 	return nil
 }
 
-// Find kecamatan by ID within a kabupaten
-func findKecamatan(kabupaten *Kabupaten, kecID string) *Kecamatan {
-	for _, kec := range kabupaten.Kec {
-		if kec.ID == kecID {
-			return &kec
+// wilayahStore answers O(1) code lookups, children listings, and prefix
+// search over wilayahData. It's an atomic.Pointer rather than a plain
+// package-level var for the same reason wilayahData is (see its comment):
+// POST /api/v1/admin/reload rebuilds it concurrently with in-flight
+// requests, so every reader goes through loadStore() instead of
+// dereferencing the shared global mid-rebuild.
+var wilayahStore atomic.Pointer[store.Store]
+
+// loadStore returns the current wilayahStore.
+func loadStore() store.Store {
+	return *wilayahStore.Load()
+}
+
+// wilayahService is the store-backed core behind /info, /info/batch,
+// /lookup, /provinsi, and /kabupaten, shared by the Fiber and NATS
+// transports; rebuilt alongside wilayahStore on every load/reload. Also an
+// atomic.Pointer so a reload can't be observed mid-swap.
+var wilayahService atomic.Pointer[service.Service]
+
+// loadService returns the current wilayahService.
+func loadService() *service.Service {
+	return wilayahService.Load()
+}
+
+// toStoreProvinsi converts the API's own Provinsi tree into store's mirrored
+// types, since store.New can't depend on package main's types.
+func toStoreProvinsi(pro []Provinsi) []store.Provinsi {
+	out := make([]store.Provinsi, len(pro))
+	for pi, p := range pro {
+		kab := make([]store.Kabupaten, len(p.Kab))
+		for ki, k := range p.Kab {
+			kec := make([]store.Kecamatan, len(k.Kec))
+			for ci, c := range k.Kec {
+				des := make([]store.Desa, len(c.Des))
+				for di, d := range c.Des {
+					des[di] = store.Desa{ID: d.ID, Nama: d.Nama}
+				}
+				kec[ci] = store.Kecamatan{ID: c.ID, Nama: c.Nama, Des: des}
+			}
+			kab[ki] = store.Kabupaten{ID: k.ID, Nama: k.Nama, Kec: kec}
 		}
+		out[pi] = store.Provinsi{ID: p.ID, Nama: p.Nama, Kab: kab}
 	}
-	return nil
+	return out
 }
 
 // healthCheck godoc
@@ -499,8 +1684,9 @@ func healthCheck(c *fiber.Ctx) error {
 		"status":  "OK",
 		"message": "Indonesian Region API is running",
 		"data_count": fiber.Map{
-			"provinces": len(wilayahData.Pro),
+			"provinces": len(wilayahData.Load().Pro),
 		},
+		"nats_connected": natsConn != nil && natsConn.IsConnected(),
 	})
 }
 
@@ -517,7 +1703,7 @@ func getStats(c *fiber.Ctx) error {
 	totalKec := 0
 	totalDesa := 0
 
-	for _, p := range wilayahData.Pro {
+	for _, p := range wilayahData.Load().Pro {
 		totalKab += len(p.Kab)
 		for _, k := range p.Kab {
 			totalKec += len(k.Kec)
@@ -528,40 +1714,127 @@ func getStats(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(fiber.Map{
-		"provinces": len(wilayahData.Pro),
+		"provinces": len(wilayahData.Load().Pro),
 		"kabupaten": totalKab,
 		"kecamatan": totalKec,
 		"desa":      totalDesa,
 	})
 }
 
+// VersionResponse is the response for GET /version: enough for a client to
+// decide whether its own cache of the dataset is stale.
+type VersionResponse struct {
+	DatasetHash string    `json:"dataset_hash" example:"8f434346648f6b96df89dda901c5176b10a6d83961dd3c1ac88b59b2dc327aa0"`
+	GeneratedAt time.Time `json:"generated_at" example:"2025-07-06T10:36:12+07:00"`
+	LoadedAt    time.Time `json:"loaded_at" example:"2025-07-06T10:40:00+07:00"`
+}
+
+// getVersion godoc
+// @Summary      Get the loaded dataset's version
+// @Description  Return the current dataset's hash, when that dataset was generated, and when this process last loaded it, so a client can invalidate its own cache deterministically instead of polling content endpoints.
+// @Tags         health
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  VersionResponse
+// @Router       /version [get]
+func getVersion(c *fiber.Ctx) error {
+	meta := currentDatasetMeta.Load()
+	return c.JSON(VersionResponse{
+		DatasetHash: meta.Hash,
+		GeneratedAt: meta.GeneratedAt,
+		LoadedAt:    meta.LoadedAt,
+	})
+}
+
+// reloadDataset godoc
+// @Summary      Reload the dataset from disk
+// @Description  Re-read the latest wilayah_final_*.json / temp_wilayah_*.json file and atomically rotate it in behind wilayahData, rebuilding the store and search index. Requires the same API key as the scraper control endpoints.
+// @Tags         health
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  VersionResponse
+// @Failure      401  {object}  ErrorResponse
+// @Failure      500  {object}  ErrorResponse
+// @Router       /admin/reload [post]
+func reloadDataset(c *fiber.Ctx) error {
+	if err := reloadWilayahData(); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	meta := currentDatasetMeta.Load()
+	return c.JSON(VersionResponse{
+		DatasetHash: meta.Hash,
+		GeneratedAt: meta.GeneratedAt,
+		LoadedAt:    meta.LoadedAt,
+	})
+}
+
 // getProvinsi godoc
 // @Summary      Get all provinces
-// @Description  Retrieve all provinces in Indonesia
+// @Description  Retrieve all provinces in Indonesia. Accepts the shared filter grammar (nama_like, nama_prefix, id_in, has_children_gte/lte, sort, order, page, per_page); using any of it switches the response to {data, meta:{total,page,per_page}} instead of a plain array.
 // @Tags         provinces
 // @Accept       json
 // @Produce      json
+// @Param        nama_like        query  string  false  "Substring filter pada nama (case-insensitive)"
+// @Param        nama_prefix      query  string  false  "Prefix filter pada nama (case-insensitive)"
+// @Param        id_in            query  string  false  "Daftar ID dipisah koma" example(02,03,05)
+// @Param        has_children_gte query  int     false  "Minimal jumlah anak langsung"
+// @Param        has_children_lte query  int     false  "Maksimal jumlah anak langsung"
+// @Param        sort             query  string  false  "nama|id|children_count"
+// @Param        order            query  string  false  "asc|desc"
+// @Param        page             query  int     false  "Halaman (default 1)"
+// @Param        per_page         query  int     false  "Ukuran halaman (default 25, maks 200)"
 // @Success      200  {array}   ProvinsiResponse
+// @Success      200  {object}  ListEnvelope
+// @Failure      400  {object}  query.Problem
 // @Router       /provinsi [get]
 func getProvinsi(c *fiber.Ctx) error {
-	var response []ProvinsiResponse
-	for _, p := range wilayahData.Pro {
-		response = append(response, ProvinsiResponse{
-			ID:   p.ID,
-			Nama: p.Nama,
-		})
+	filter, active, problem := query.Parse(queryParams(c))
+	if problem != nil {
+		return c.Status(400).JSON(problem)
+	}
+
+	if !active {
+		var response []ProvinsiResponse
+		for _, p := range wilayahData.Load().Pro {
+			response = append(response, ProvinsiResponse{
+				ID:   p.ID,
+				Nama: p.Nama,
+			})
+		}
+		return c.JSON(response)
+	}
+
+	items := make([]query.Item, len(wilayahData.Load().Pro))
+	for i, p := range wilayahData.Load().Pro {
+		items[i] = query.Item{ID: p.ID, Nama: p.Nama, ChildrenCount: len(p.Kab)}
+	}
+	page, meta := query.Apply(items, filter)
+
+	response := make([]ProvinsiResponse, len(page))
+	for i, it := range page {
+		response[i] = ProvinsiResponse{ID: it.ID, Nama: it.Nama}
 	}
-	return c.JSON(response)
+	return c.JSON(ListEnvelope{Data: response, Meta: meta})
 }
 
 // getKabupaten godoc
 // @Summary      Get kabupaten/kota by province
-// @Description  Retrieve all kabupaten/kota in a specific province
+// @Description  Retrieve all kabupaten/kota in a specific province. Accepts the shared filter grammar (nama_like, nama_prefix, id_in, has_children_gte/lte, sort, order, page, per_page); using any of it switches the response to {data, meta:{total,page,per_page}} instead of a plain array.
 // @Tags         kabupaten
 // @Accept       json
 // @Produce      json
-// @Param        pro   query     string  true  "Province ID (2 digits)" example(73)
+// @Param        pro              query     string  true  "Province ID (2 digits)" example(73)
+// @Param        nama_like        query  string  false  "Substring filter pada nama (case-insensitive)"
+// @Param        nama_prefix      query  string  false  "Prefix filter pada nama (case-insensitive)"
+// @Param        id_in            query  string  false  "Daftar ID dipisah koma" example(02,03,05)
+// @Param        has_children_gte query  int     false  "Minimal jumlah anak langsung"
+// @Param        has_children_lte query  int     false  "Maksimal jumlah anak langsung"
+// @Param        sort             query  string  false  "nama|id|children_count"
+// @Param        order            query  string  false  "asc|desc"
+// @Param        page             query  int     false  "Halaman (default 1)"
+// @Param        per_page         query  int     false  "Ukuran halaman (default 25, maks 200)"
 // @Success      200   {array}   KabupatenResponse
+// @Success      200   {object}  ListEnvelope
 // @Failure      400   {object}  ErrorResponse
 // @Failure      404   {object}  ErrorResponse
 // @Router       /kabupaten [get]
@@ -573,34 +1846,62 @@ func getKabupaten(c *fiber.Ctx) error {
 		})
 	}
 
-	provinsi := findProvinsi(proID)
-	if provinsi == nil {
+	children, ok := loadStore().Children(proID)
+	if !ok {
 		return c.Status(404).JSON(fiber.Map{
 			"error": "Province not found",
 		})
 	}
 
-	var response []KabupatenResponse
-	for _, k := range provinsi.Kab {
-		response = append(response, KabupatenResponse{
-			ID:   k.ID,
-			Nama: k.Nama,
-		})
+	filter, active, problem := query.Parse(queryParams(c), "pro")
+	if problem != nil {
+		return c.Status(400).JSON(problem)
+	}
+
+	if !active {
+		var response []KabupatenResponse
+		for _, entry := range children {
+			response = append(response, KabupatenResponse{
+				ID:   entry.Kabupaten.ID,
+				Nama: entry.Kabupaten.Nama,
+			})
+		}
+		return c.JSON(response)
+	}
+
+	items := make([]query.Item, len(children))
+	for i, entry := range children {
+		items[i] = query.Item{ID: entry.Kabupaten.ID, Nama: entry.Kabupaten.Nama, ChildrenCount: len(entry.Kabupaten.Kec)}
 	}
+	page, meta := query.Apply(items, filter)
 
-	return c.JSON(response)
+	response := make([]KabupatenResponse, len(page))
+	for i, it := range page {
+		response[i] = KabupatenResponse{ID: it.ID, Nama: it.Nama}
+	}
+	return c.JSON(ListEnvelope{Data: response, Meta: meta})
 }
 
 // getKecamatan godoc
 // @Summary      Get kecamatan by province and kabupaten
-// @Description  Retrieve all kecamatan in a specific kabupaten. Can use separate parameters (pro, kab) or combined parameter (kec)
+// @Description  Retrieve all kecamatan in a specific kabupaten. Can use separate parameters (pro, kab) or combined parameter (kec). Accepts the shared filter grammar (nama_like, nama_prefix, id_in, has_children_gte/lte, sort, order, page, per_page); using any of it switches the response to {data, meta:{total,page,per_page}} instead of a plain array.
 // @Tags         kecamatan
 // @Accept       json
 // @Produce      json
-// @Param        pro   query     string  false  "Province ID (2 digits)" example(73)
-// @Param        kab   query     string  false  "Kabupaten ID (2 digits)" example(02)
-// @Param        kec   query     string  false  "Combined code: Province + Kabupaten (4 digits)" example(7302)
+// @Param        pro              query     string  false  "Province ID (2 digits)" example(73)
+// @Param        kab              query     string  false  "Kabupaten ID (2 digits)" example(02)
+// @Param        kec              query     string  false  "Combined code: Province + Kabupaten (4 digits)" example(7302)
+// @Param        nama_like        query  string  false  "Substring filter pada nama (case-insensitive)"
+// @Param        nama_prefix      query  string  false  "Prefix filter pada nama (case-insensitive)"
+// @Param        id_in            query  string  false  "Daftar ID dipisah koma" example(010,020)
+// @Param        has_children_gte query  int     false  "Minimal jumlah anak langsung"
+// @Param        has_children_lte query  int     false  "Maksimal jumlah anak langsung"
+// @Param        sort             query  string  false  "nama|id|children_count"
+// @Param        order            query  string  false  "asc|desc"
+// @Param        page             query  int     false  "Halaman (default 1)"
+// @Param        per_page         query  int     false  "Ukuran halaman (default 25, maks 200)"
 // @Success      200   {array}   KecamatanResponse
+// @Success      200   {object}  ListEnvelope
 // @Failure      400   {object}  ErrorResponse
 // @Failure      404   {object}  ErrorResponse
 // @Router       /kecamatan [get]
@@ -621,34 +1922,51 @@ func getKecamatan(c *fiber.Ctx) error {
 		})
 	}
 
-	provinsi := findProvinsi(proID)
-	if provinsi == nil {
+	if _, ok := loadStore().ByCode(proID); !ok {
 		return c.Status(404).JSON(fiber.Map{
 			"error": "Province not found",
 		})
 	}
 
-	kabupaten := findKabupaten(provinsi, kabID)
-	if kabupaten == nil {
+	children, ok := loadStore().Children(proID + kabID)
+	if !ok {
 		return c.Status(404).JSON(fiber.Map{
 			"error": "Kabupaten/Kota not found",
 		})
 	}
 
-	var response []KecamatanResponse
-	for _, kec := range kabupaten.Kec {
-		response = append(response, KecamatanResponse{
-			ID:   kec.ID,
-			Nama: kec.Nama,
-		})
+	filter, active, problem := query.Parse(queryParams(c), "pro", "kab", "kec")
+	if problem != nil {
+		return c.Status(400).JSON(problem)
+	}
+
+	if !active {
+		var response []KecamatanResponse
+		for _, entry := range children {
+			response = append(response, KecamatanResponse{
+				ID:   entry.Kecamatan.ID,
+				Nama: entry.Kecamatan.Nama,
+			})
+		}
+		return c.JSON(response)
+	}
+
+	items := make([]query.Item, len(children))
+	for i, entry := range children {
+		items[i] = query.Item{ID: entry.Kecamatan.ID, Nama: entry.Kecamatan.Nama, ChildrenCount: len(entry.Kecamatan.Des)}
 	}
+	page, meta := query.Apply(items, filter)
 
-	return c.JSON(response)
+	response := make([]KecamatanResponse, len(page))
+	for i, it := range page {
+		response[i] = KecamatanResponse{ID: it.ID, Nama: it.Nama}
+	}
+	return c.JSON(ListEnvelope{Data: response, Meta: meta})
 }
 
 // getDesa godoc
 // @Summary      Get desa/kelurahan by province, kabupaten, and kecamatan
-// @Description  Retrieve all desa/kelurahan in a specific kecamatan. Can use separate parameters (pro, kab, kec) or combined parameter (desa)
+// @Description  Retrieve all desa/kelurahan in a specific kecamatan. Can use separate parameters (pro, kab, kec) or combined parameter (desa). With no filter at all, walks every desa in the dataset (~80k rows), bounded by ?timeout=. When pro/kab/kec is given, also accepts the shared filter grammar (nama_like, nama_prefix, id_in, has_children_gte/lte, sort, order, page, per_page); using any of it switches the response to {data, meta:{total,page,per_page}} instead of a plain array.
 // @Tags         desa
 // @Accept       json
 // @Produce      json
@@ -656,7 +1974,19 @@ func getKecamatan(c *fiber.Ctx) error {
 // @Param        kab   query     string  false  "Kabupaten ID (2 digits)" example(02)
 // @Param        kec   query     string  false  "Kecamatan ID (3 digits)" example(010)
 // @Param        desa  query     string  false  "Combined code: Province + Kabupaten + Kecamatan (7 digits)" example(7302010)
+// @Param        timeout query   string  false "Batas waktu saat tanpa filter, mis. 250ms (dibatasi SEARCH_MAX_TIMEOUT)" example(250ms)
+// @Param        nama_like        query  string  false  "Substring filter pada nama (case-insensitive), hanya berlaku saat pro/kab/kec diisi"
+// @Param        nama_prefix      query  string  false  "Prefix filter pada nama (case-insensitive), hanya berlaku saat pro/kab/kec diisi"
+// @Param        id_in            query  string  false  "Daftar ID dipisah koma, hanya berlaku saat pro/kab/kec diisi" example(001,002)
+// @Param        has_children_gte query  int     false  "Minimal jumlah anak langsung (selalu 0 untuk desa)"
+// @Param        has_children_lte query  int     false  "Maksimal jumlah anak langsung (selalu 0 untuk desa)"
+// @Param        sort             query  string  false  "nama|id|children_count, hanya berlaku saat pro/kab/kec diisi"
+// @Param        order            query  string  false  "asc|desc, hanya berlaku saat pro/kab/kec diisi"
+// @Param        page             query  int     false  "Halaman (default 1), hanya berlaku saat pro/kab/kec diisi"
+// @Param        per_page         query  int     false  "Ukuran halaman (default 25, maks 200), hanya berlaku saat pro/kab/kec diisi"
 // @Success      200   {array}   DesaResponse
+// @Success      200   {object}  DesaListResponse
+// @Success      200   {object}  ListEnvelope
 // @Failure      400   {object}  ErrorResponse
 // @Failure      404   {object}  ErrorResponse
 // @Router       /desa [get]
@@ -673,42 +2003,93 @@ func getDesa(c *fiber.Ctx) error {
 		kecID = desaParam[4:]
 	}
 
+	if proID == "" && kabID == "" && kecID == "" {
+		ctx, cancel := requestDeadline(c)
+		defer cancel()
+		resp := listAllDesa(ctx)
+		if resp.Partial {
+			metrics.RecordAPIDeadlineExceeded("desa")
+		}
+		return c.JSON(resp)
+	}
+
 	if proID == "" || kabID == "" || kecID == "" {
 		return c.Status(400).JSON(fiber.Map{
 			"error": "Parameters 'pro', 'kab', and 'kec' are required, or use 'desa' with 7-digit code",
 		})
 	}
 
-	provinsi := findProvinsi(proID)
-	if provinsi == nil {
+	if _, ok := loadStore().ByCode(proID); !ok {
 		return c.Status(404).JSON(fiber.Map{
 			"error": "Province not found",
 		})
 	}
 
-	kabupaten := findKabupaten(provinsi, kabID)
-	if kabupaten == nil {
+	if _, ok := loadStore().ByCode(proID + kabID); !ok {
 		return c.Status(404).JSON(fiber.Map{
 			"error": "Kabupaten/Kota not found",
 		})
 	}
 
-	kecamatan := findKecamatan(kabupaten, kecID)
-	if kecamatan == nil {
+	children, ok := loadStore().Children(proID + kabID + kecID)
+	if !ok {
 		return c.Status(404).JSON(fiber.Map{
 			"error": "Kecamatan not found",
 		})
 	}
 
-	var response []DesaResponse
-	for _, d := range kecamatan.Des {
-		response = append(response, DesaResponse{
-			ID:   d.ID,
-			Nama: d.Nama,
-		})
+	filter, active, problem := query.Parse(queryParams(c), "pro", "kab", "kec", "desa", "timeout")
+	if problem != nil {
+		return c.Status(400).JSON(problem)
+	}
+
+	if !active {
+		var response []DesaResponse
+		for _, entry := range children {
+			response = append(response, DesaResponse{
+				ID:   entry.Desa.ID,
+				Nama: entry.Desa.Nama,
+			})
+		}
+		return c.JSON(response)
+	}
+
+	items := make([]query.Item, len(children))
+	for i, entry := range children {
+		items[i] = query.Item{ID: entry.Desa.ID, Nama: entry.Desa.Nama}
+	}
+	page, meta := query.Apply(items, filter)
+
+	response := make([]DesaResponse, len(page))
+	for i, it := range page {
+		response[i] = DesaResponse{ID: it.ID, Nama: it.Nama}
 	}
+	return c.JSON(ListEnvelope{Data: response, Meta: meta})
+}
 
-	return c.JSON(response)
+// listAllDesa walks every provinsi/kabupaten/kecamatan/desa in wilayahData
+// and returns every desa, for GET /desa when no pro/kab/kec/desa filter is
+// given. ctx.Err() is checked every searchCheckInterval desa so a slow
+// client streaming the full ~80k rows can't pin this goroutine past its
+// deadline (see requestDeadline); on deadline, whatever was collected so
+// far is returned with Partial set instead of failing the request.
+func listAllDesa(ctx context.Context) DesaListResponse {
+	response := make([]DesaResponse, 0, 1024)
+	i := 0
+	for _, p := range wilayahData.Load().Pro {
+		for _, k := range p.Kab {
+			for _, kec := range k.Kec {
+				for _, d := range kec.Des {
+					if i%searchCheckInterval == 0 && ctx.Err() != nil {
+						return DesaListResponse{Count: len(response), Results: response, Partial: true}
+					}
+					response = append(response, DesaResponse{ID: d.ID, Nama: d.Nama})
+					i++
+				}
+			}
+		}
+	}
+	return DesaListResponse{Count: len(response), Results: response}
 }
 
 // getWilayahInfo godoc
@@ -730,141 +2111,254 @@ func getWilayahInfo(c *fiber.Ctx) error {
 		})
 	}
 
-	codeLen := len(code)
-	var result fiber.Map
+	result, status, errMsg := resolveWilayahInfo(code)
+	if errMsg != "" {
+		return c.Status(status).JSON(fiber.Map{"error": errMsg})
+	}
+	return c.JSON(result)
+}
 
-	switch codeLen {
-	case 2: // Province code
-		provinsi := findProvinsi(code)
-		if provinsi == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Province not found",
-			})
-		}
-		result = fiber.Map{
-			"type":     "provinsi",
-			"id":       provinsi.ID,
-			"nama":     provinsi.Nama,
-			"children": len(provinsi.Kab),
-		}
+// resolveWilayahInfo is the fiber.Ctx-independent core of getWilayahInfo and
+// POST /info/batch /lookup; it's a thin adapter over wilayahService.Info so
+// every transport (HTTP, legacy NATS, NATS v1) resolves a code through the
+// same service call. A non-empty errMsg means the lookup failed; status is
+// the HTTP status that error would map to.
+func resolveWilayahInfo(code string) (result fiber.Map, status int, errMsg string) {
+	m, status, errMsg := loadService().Info(code)
+	return fiber.Map(m), status, errMsg
+}
 
-	case 4: // Kabupaten code (PPKK)
-		proID := code[:2]
-		kabID := code[2:]
+// batchResolveWilayah godoc
+// @Summary      Resolve many region codes in one request
+// @Description  Resolve up to 1000 region codes (any mix of 2/4/7/10 digits) in a single call, returning one InfoResponse-shaped item per code in the same order. Codes that don't resolve get an Error field instead of failing the whole batch.
+// @Tags         info
+// @Accept       json
+// @Produce      json
+// @Param        request body BatchInfoRequest true "Codes to resolve"
+// @Success      200   {object}  BatchInfoResponse
+// @Failure      400   {object}  ErrorResponse
+// @Router       /info/batch [post]
+func batchResolveWilayah(c *fiber.Ctx) error {
+	var req BatchInfoRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if len(req.Codes) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "codes must not be empty"})
+	}
+	if len(req.Codes) > batchInfoMaxCodes {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fmt.Sprintf("codes exceeds the %d code limit per request", batchInfoMaxCodes),
+		})
+	}
 
-		provinsi := findProvinsi(proID)
-		if provinsi == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Province not found",
-			})
+	items := make([]BatchInfoItem, len(req.Codes))
+	for i, code := range req.Codes {
+		result, _, errMsg := resolveWilayahInfo(code)
+		if errMsg != "" {
+			items[i] = BatchInfoItem{Code: code, Error: errMsg}
+			continue
 		}
+		items[i] = BatchInfoItem{Code: code, Info: result}
+	}
 
-		kabupaten := findKabupaten(provinsi, kabID)
-		if kabupaten == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Kabupaten/Kota not found",
-			})
-		}
+	return c.JSON(BatchInfoResponse{
+		Count: len(items),
+		Items: items,
+	})
+}
+
+// lookupWilayah godoc
+// @Summary      Resolve many region codes, keyed by code
+// @Description  Resolve up to 1000 region codes (any mix of 2/4/7/10 digits) in a single call, returning a map from code to its resolved info. Duplicate codes in the request are deduped. Codes that don't resolve get an Error field instead of failing the whole batch.
+// @Tags         info
+// @Accept       json
+// @Produce      json
+// @Param        request body LookupRequest true "Codes to resolve"
+// @Success      200   {object}  LookupResponse
+// @Failure      400   {object}  ErrorResponse
+// @Router       /lookup [post]
+func lookupWilayah(c *fiber.Ctx) error {
+	var req LookupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if len(req.Codes) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "codes must not be empty"})
+	}
+	if len(req.Codes) > batchInfoMaxCodes {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fmt.Sprintf("codes exceeds the %d code limit per request", batchInfoMaxCodes),
+		})
+	}
 
-		result = fiber.Map{
-			"type":     "kabupaten",
-			"id":       kabupaten.ID,
-			"nama":     kabupaten.Nama,
-			"provinsi": fiber.Map{"id": provinsi.ID, "nama": provinsi.Nama},
-			"children": len(kabupaten.Kec),
+	result := make(LookupResponse, len(req.Codes))
+	for _, code := range req.Codes {
+		if _, done := result[code]; done {
+			continue
+		}
+		info, _, errMsg := resolveWilayahInfo(code)
+		if errMsg != "" {
+			result[code] = LookupResult{Error: errMsg}
+			continue
 		}
+		result[code] = LookupResult{Info: info}
+	}
 
-	case 7: // Kecamatan code (PPKKNNN)
-		proID := code[:2]
-		kabID := code[2:4]
-		kecID := code[4:]
+	return c.JSON(result)
+}
 
-		provinsi := findProvinsi(proID)
-		if provinsi == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Province not found",
-			})
-		}
+// batchKabupaten godoc
+// @Summary      Get kabupaten/kota for many provinces at once
+// @Description  Given a list of province IDs, return each one's kabupaten/kota list keyed by province ID, so a client can populate many provinces' regency dropdowns in one call instead of N requests.
+// @Tags         kabupaten
+// @Accept       json
+// @Produce      json
+// @Param        request body KabupatenBatchRequest true "Province IDs"
+// @Success      200   {object}  KabupatenBatchResponse
+// @Failure      400   {object}  ErrorResponse
+// @Router       /kabupaten/batch [post]
+func batchKabupaten(c *fiber.Ctx) error {
+	var req KabupatenBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if len(req.Pro) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "pro must not be empty"})
+	}
+	if len(req.Pro) > batchInfoMaxCodes {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fmt.Sprintf("pro exceeds the %d code limit per request", batchInfoMaxCodes),
+		})
+	}
 
-		kabupaten := findKabupaten(provinsi, kabID)
-		if kabupaten == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Kabupaten/Kota not found",
-			})
+	result := make(KabupatenBatchResponse, len(req.Pro))
+	for _, proID := range req.Pro {
+		if _, done := result[proID]; done {
+			continue
 		}
-
-		kecamatan := findKecamatan(kabupaten, kecID)
-		if kecamatan == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Kecamatan not found",
-			})
+		children, ok := loadStore().Children(proID)
+		if !ok {
+			result[proID] = KabupatenBatchItem{Error: "Province not found"}
+			continue
 		}
-
-		result = fiber.Map{
-			"type":      "kecamatan",
-			"id":        kecamatan.ID,
-			"nama":      kecamatan.Nama,
-			"kabupaten": fiber.Map{"id": kabupaten.ID, "nama": kabupaten.Nama},
-			"provinsi":  fiber.Map{"id": provinsi.ID, "nama": provinsi.Nama},
-			"children":  len(kecamatan.Des),
+		items := make([]KabupatenResponse, len(children))
+		for i, entry := range children {
+			items[i] = KabupatenResponse{ID: entry.Kabupaten.ID, Nama: entry.Kabupaten.Nama}
 		}
+		result[proID] = KabupatenBatchItem{Items: items}
+	}
 
-	case 10: // Desa code (PPKKNNNXXX)
-		proID := code[:2]
-		kabID := code[2:4]
-		kecID := code[4:7]
-		desaID := code[7:]
-
-		provinsi := findProvinsi(proID)
-		if provinsi == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Province not found",
-			})
-		}
+	return c.JSON(result)
+}
 
-		kabupaten := findKabupaten(provinsi, kabID)
-		if kabupaten == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Kabupaten/Kota not found",
-			})
-		}
+// batchKecamatan godoc
+// @Summary      Get kecamatan for many kabupaten at once
+// @Description  Given a list of combined pro+kab codes (4 digits), return each one's kecamatan list keyed by that code, so a client can populate many kabupaten's district dropdowns in one call instead of N requests.
+// @Tags         kecamatan
+// @Accept       json
+// @Produce      json
+// @Param        request body KecamatanBatchRequest true "Combined pro+kab codes"
+// @Success      200   {object}  KecamatanBatchResponse
+// @Failure      400   {object}  ErrorResponse
+// @Router       /kecamatan/batch [post]
+func batchKecamatan(c *fiber.Ctx) error {
+	var req KecamatanBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if len(req.Kab) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "kab must not be empty"})
+	}
+	if len(req.Kab) > batchInfoMaxCodes {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fmt.Sprintf("kab exceeds the %d code limit per request", batchInfoMaxCodes),
+		})
+	}
 
-		kecamatan := findKecamatan(kabupaten, kecID)
-		if kecamatan == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Kecamatan not found",
-			})
+	result := make(KecamatanBatchResponse, len(req.Kab))
+	for _, code := range req.Kab {
+		if _, done := result[code]; done {
+			continue
 		}
-
-		var desa *Desa
-		for _, d := range kecamatan.Des {
-			if d.ID == desaID {
-				desa = &d
-				break
-			}
+		if len(code) != 4 {
+			result[code] = KecamatanBatchItem{Error: "Invalid code length. Use 4 digits (province + kabupaten)"}
+			continue
 		}
-
-		if desa == nil {
-			return c.Status(404).JSON(fiber.Map{
-				"error": "Desa/Kelurahan not found",
-			})
+		proID := code[:2]
+		if _, ok := loadStore().ByCode(proID); !ok {
+			result[code] = KecamatanBatchItem{Error: "Province not found"}
+			continue
 		}
-
-		result = fiber.Map{
-			"type":      "desa",
-			"id":        desa.ID,
-			"nama":      desa.Nama,
-			"kecamatan": fiber.Map{"id": kecamatan.ID, "nama": kecamatan.Nama},
-			"kabupaten": fiber.Map{"id": kabupaten.ID, "nama": kabupaten.Nama},
-			"provinsi":  fiber.Map{"id": provinsi.ID, "nama": provinsi.Nama},
+		children, ok := loadStore().Children(code)
+		if !ok {
+			result[code] = KecamatanBatchItem{Error: "Kabupaten/Kota not found"}
+			continue
+		}
+		items := make([]KecamatanResponse, len(children))
+		for i, entry := range children {
+			items[i] = KecamatanResponse{ID: entry.Kecamatan.ID, Nama: entry.Kecamatan.Nama}
 		}
+		result[code] = KecamatanBatchItem{Items: items}
+	}
 
-	default:
+	return c.JSON(result)
+}
+
+// batchDesa godoc
+// @Summary      Get desa/kelurahan for many kecamatan at once
+// @Description  Given a list of combined pro+kab+kec codes (7 digits), return each one's desa list keyed by that code, so a client can populate many kecamatan's village dropdowns in one call instead of N requests.
+// @Tags         desa
+// @Accept       json
+// @Produce      json
+// @Param        request body DesaBatchRequest true "Combined pro+kab+kec codes"
+// @Success      200   {object}  DesaBatchResponse
+// @Failure      400   {object}  ErrorResponse
+// @Router       /desa/batch [post]
+func batchDesa(c *fiber.Ctx) error {
+	var req DesaBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if len(req.Kec) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "kec must not be empty"})
+	}
+	if len(req.Kec) > batchInfoMaxCodes {
 		return c.Status(400).JSON(fiber.Map{
-			"error": "Invalid code length. Use 2 digits for province, 4 for kabupaten, 7 for kecamatan, or 10 for desa",
+			"error": fmt.Sprintf("kec exceeds the %d code limit per request", batchInfoMaxCodes),
 		})
 	}
 
+	result := make(DesaBatchResponse, len(req.Kec))
+	for _, code := range req.Kec {
+		if _, done := result[code]; done {
+			continue
+		}
+		if len(code) != 7 {
+			result[code] = DesaBatchItem{Error: "Invalid code length. Use 7 digits (province + kabupaten + kecamatan)"}
+			continue
+		}
+		proID, kabID := code[:2], code[2:4]
+		if _, ok := loadStore().ByCode(proID); !ok {
+			result[code] = DesaBatchItem{Error: "Province not found"}
+			continue
+		}
+		if _, ok := loadStore().ByCode(proID + kabID); !ok {
+			result[code] = DesaBatchItem{Error: "Kabupaten/Kota not found"}
+			continue
+		}
+		children, ok := loadStore().Children(code)
+		if !ok {
+			result[code] = DesaBatchItem{Error: "Kecamatan not found"}
+			continue
+		}
+		items := make([]DesaResponse, len(children))
+		for i, entry := range children {
+			items[i] = DesaResponse{ID: entry.Desa.ID, Nama: entry.Desa.Nama}
+		}
+		result[code] = DesaBatchItem{Items: items}
+	}
+
 	return c.JSON(result)
 }
 
@@ -878,7 +2372,10 @@ func getWilayahInfo(c *fiber.Ctx) error {
 // @Param        limit  query     int     false "Batas jumlah hasil (1-200, default 50)"   example(20)
 // @Param        offset query     int     false "Offset/pagination start (default 0)"       example(0)
 // @Param        level  query     string  false "Batasi level: desa|kecamatan|kabupaten|provinsi" example(desa)
-// @Param        fuzzy  query     bool    false "Aktifkan fuzzy match (Levenshtein)"        example(false)
+// @Param        fuzzy  query     bool    false "Aktifkan fuzzy match (BK-tree + Levenshtein)" example(false)
+// @Param        fuzzy_distance query int  false "Batas jarak edit untuk fuzzy match (default len(q)/4, min 1)" example(2)
+// @Param        explain query   bool    false "Sertakan komponen skor (match_type/jaccard/distance) di tiap item" example(false)
+// @Param        timeout query   string  false "Batas waktu request, mis. 250ms (dibatasi SEARCH_MAX_TIMEOUT); saat habis, respons 200 dengan partial=true" example(250ms)
 // @Success      200    {object}  SearchResponse
 // @Failure      400    {object}  ErrorResponse
 // @Router       /search [get]
@@ -894,118 +2391,129 @@ func searchWilayah(c *fiber.Ctx) error {
 	}
 
 	limit := c.QueryInt("limit", 50)
-	if limit <= 0 || limit > 200 {
-		limit = 50
-	}
 	offset := c.QueryInt("offset", 0)
-	if offset < 0 {
-		offset = 0
-	}
 	level := strings.ToLower(strings.TrimSpace(c.Query("level")))
 	fuzzyParam := strings.TrimSpace(strings.ToLower(c.Query("fuzzy")))
 	fuzzy := false
 	if b, err := strconv.ParseBool(fuzzyParam); err == nil {
 		fuzzy = b
 	}
+	fuzzyDistance := c.QueryInt("fuzzy_distance", 0)
+	explain := false
+	if b, err := strconv.ParseBool(strings.TrimSpace(c.Query("explain"))); err == nil {
+		explain = b
+	}
+
+	ctx, cancel := requestDeadline(c)
+	defer cancel()
+
+	resp := runSearch(ctx, q, limit, offset, level, fuzzy, fuzzyDistance, explain)
+	if resp.Partial {
+		metrics.RecordAPIDeadlineExceeded("search")
+	}
+	return c.JSON(resp)
+}
 
-	if searchIndex == nil {
-		buildSearchIndex()
+// runSearch is the fiber.Ctx-independent core of searchWilayah, shared with
+// the NATS wilayah.search subject so both transports walk the exact same
+// trigram/prefix/substring/BK-tree fuzzy pipeline over searchIndex. ctx is
+// checked every searchCheckInterval iterations in each level's candidate
+// loop; once it's done, runSearch stops early and returns whatever it has
+// collected so far with Partial set, rather than erroring the request out.
+func runSearch(ctx context.Context, q string, limit, offset int, level string, fuzzy bool, fuzzyDistance int, explain bool) SearchResponse {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
 	}
+	level = strings.ToLower(strings.TrimSpace(level))
+
+	idx := ensureSearchIndex()
 
 	nq := normalizeName(q)
 	// helper buckets by match priority
 	prefixItems := make([]SearchItem, 0)
 	containsItems := make([]SearchItem, 0)
 	fuzzyItems := make([]SearchItem, 0)
+	// matched tracks every entry already placed in prefixItems/containsItems
+	// so the fuzzy BK-tree pass below doesn't return it a second time.
+	matched := make(map[string]bool)
 
-	addDes := func(e desaIndex) {
+	addDes := func(e desaIndex, jaccard float64) {
 		item := SearchItem{Type: "desa", Label: e.Label}
 		item.IDs.Pro, item.IDs.Kab, item.IDs.Kec, item.IDs.Des = e.Pro, e.Kab, e.Kec, e.Des
-		if strings.HasPrefix(e.NameNorm, nq) {
+		key := "desa:" + e.Pro + e.Kab + e.Kec + e.Des
+		switch {
+		case strings.HasPrefix(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "prefix", Jaccard: jaccard}
+			}
 			prefixItems = append(prefixItems, item)
-		} else if strings.Contains(e.NameNorm, nq) {
-			containsItems = append(containsItems, item)
-		} else if fuzzy {
-			// quick check first-letter to cut down distance calcs
-			if e.NameNorm != "" && nq != "" && e.NameNorm[0] == nq[0] {
-				// dynamic threshold by length
-				maxD := 2
-				ln := len([]rune(e.NameNorm))
-				if ln <= 5 {
-					maxD = 1
-				} else if ln > 12 {
-					maxD = 3
-				}
-				if levenshtein(e.NameNorm, nq) <= maxD {
-					fuzzyItems = append(fuzzyItems, item)
-				}
+			matched[key] = true
+		case strings.Contains(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "contains", Jaccard: jaccard}
 			}
+			containsItems = append(containsItems, item)
+			matched[key] = true
 		}
 	}
-	addKec := func(e kecIndex) {
+	addKec := func(e kecIndex, jaccard float64) {
 		item := SearchItem{Type: "kecamatan", Label: e.Label}
 		item.IDs.Pro, item.IDs.Kab, item.IDs.Kec = e.Pro, e.Kab, e.Kec
-		if strings.HasPrefix(e.NameNorm, nq) {
+		key := "kecamatan:" + e.Pro + e.Kab + e.Kec
+		switch {
+		case strings.HasPrefix(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "prefix", Jaccard: jaccard}
+			}
 			prefixItems = append(prefixItems, item)
-		} else if strings.Contains(e.NameNorm, nq) {
-			containsItems = append(containsItems, item)
-		} else if fuzzy {
-			if e.NameNorm != "" && nq != "" && e.NameNorm[0] == nq[0] {
-				maxD := 2
-				ln := len([]rune(e.NameNorm))
-				if ln <= 5 {
-					maxD = 1
-				} else if ln > 12 {
-					maxD = 3
-				}
-				if levenshtein(e.NameNorm, nq) <= maxD {
-					fuzzyItems = append(fuzzyItems, item)
-				}
+			matched[key] = true
+		case strings.Contains(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "contains", Jaccard: jaccard}
 			}
+			containsItems = append(containsItems, item)
+			matched[key] = true
 		}
 	}
-	addKab := func(e kabIndex) {
+	addKab := func(e kabIndex, jaccard float64) {
 		item := SearchItem{Type: "kabupaten", Label: e.Label}
 		item.IDs.Pro, item.IDs.Kab = e.Pro, e.Kab
-		if strings.HasPrefix(e.NameNorm, nq) {
+		key := "kabupaten:" + e.Pro + e.Kab
+		switch {
+		case strings.HasPrefix(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "prefix", Jaccard: jaccard}
+			}
 			prefixItems = append(prefixItems, item)
-		} else if strings.Contains(e.NameNorm, nq) {
-			containsItems = append(containsItems, item)
-		} else if fuzzy {
-			if e.NameNorm != "" && nq != "" && e.NameNorm[0] == nq[0] {
-				maxD := 2
-				ln := len([]rune(e.NameNorm))
-				if ln <= 5 {
-					maxD = 1
-				} else if ln > 12 {
-					maxD = 3
-				}
-				if levenshtein(e.NameNorm, nq) <= maxD {
-					fuzzyItems = append(fuzzyItems, item)
-				}
+			matched[key] = true
+		case strings.Contains(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "contains", Jaccard: jaccard}
 			}
+			containsItems = append(containsItems, item)
+			matched[key] = true
 		}
 	}
-	addPro := func(e provIndex) {
+	addPro := func(e provIndex, jaccard float64) {
 		item := SearchItem{Type: "provinsi", Label: e.Label}
 		item.IDs.Pro = e.Pro
-		if strings.HasPrefix(e.NameNorm, nq) {
+		key := "provinsi:" + e.Pro
+		switch {
+		case strings.HasPrefix(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "prefix", Jaccard: jaccard}
+			}
 			prefixItems = append(prefixItems, item)
-		} else if strings.Contains(e.NameNorm, nq) {
-			containsItems = append(containsItems, item)
-		} else if fuzzy {
-			if e.NameNorm != "" && nq != "" && e.NameNorm[0] == nq[0] {
-				maxD := 2
-				ln := len([]rune(e.NameNorm))
-				if ln <= 5 {
-					maxD = 1
-				} else if ln > 12 {
-					maxD = 3
-				}
-				if levenshtein(e.NameNorm, nq) <= maxD {
-					fuzzyItems = append(fuzzyItems, item)
-				}
+			matched[key] = true
+		case strings.Contains(e.NameNorm, nq):
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "contains", Jaccard: jaccard}
 			}
+			containsItems = append(containsItems, item)
+			matched[key] = true
 		}
 	}
 
@@ -1015,24 +2523,132 @@ func searchWilayah(c *fiber.Ctx) error {
 	includeKab := level == "" || level == "kabupaten"
 	includePro := level == "" || level == "provinsi"
 
-	if includeDes {
-		for _, e := range searchIndex.Desa {
-			addDes(e)
+	// partial is set the first time ctx runs out mid-scan; once set, every
+	// remaining add-loop below is skipped so the response goes out with
+	// whatever was collected so far instead of erroring the request out.
+	partial := false
+	ctxDone := func(i int) bool {
+		if i%searchCheckInterval != 0 {
+			return false
 		}
+		return ctx.Err() != nil
 	}
-	if includeKec {
-		for _, e := range searchIndex.Kecamatan {
-			addKec(e)
+
+	// Candidate generation runs against the trigram index instead of
+	// scanning every entry at a level: only rows sharing enough shingles
+	// with nq are even exact-checked for prefix/contains. Each loop checks
+	// ctx.Err() every searchCheckInterval iterations so a cancelled or
+	// timed-out request (see requestDeadline) bails out early.
+	if includeDes && !partial {
+		for i, cand := range searchCandidates(idx.DesaTrigram, nq, func(id uint32) string { return idx.Desa[id].NameNorm }) {
+			if ctxDone(i) {
+				partial = true
+				break
+			}
+			addDes(idx.Desa[cand.RowID], cand.Jaccard)
 		}
 	}
-	if includeKab {
-		for _, e := range searchIndex.Kabupaten {
-			addKab(e)
+	if includeKec && !partial {
+		for i, cand := range searchCandidates(idx.KecamatanTrigram, nq, func(id uint32) string { return idx.Kecamatan[id].NameNorm }) {
+			if ctxDone(i) {
+				partial = true
+				break
+			}
+			addKec(idx.Kecamatan[cand.RowID], cand.Jaccard)
+		}
+	}
+	if includeKab && !partial {
+		for i, cand := range searchCandidates(idx.KabupatenTrigram, nq, func(id uint32) string { return idx.Kabupaten[id].NameNorm }) {
+			if ctxDone(i) {
+				partial = true
+				break
+			}
+			addKab(idx.Kabupaten[cand.RowID], cand.Jaccard)
+		}
+	}
+	if includePro && !partial {
+		for i, cand := range searchCandidates(idx.ProvinsiTrigram, nq, func(id uint32) string { return idx.Provinsi[id].NameNorm }) {
+			if ctxDone(i) {
+				partial = true
+				break
+			}
+			addPro(idx.Provinsi[cand.RowID], cand.Jaccard)
 		}
 	}
-	if includePro {
-		for _, e := range searchIndex.Provinsi {
-			addPro(e)
+
+	// Fuzzy pass: query each level's BK-tree with a bounded edit distance
+	// instead of scanning every entry. Results are capped at limit+offset
+	// (the most this request could ever page through) before being appended,
+	// so a large fuzzy hit count doesn't do unbounded extra work downstream.
+	// Skipped once partial is set: the exact pass already ran out of time.
+	if fuzzy && !partial {
+		maxD := fuzzyDistance
+		if maxD <= 0 {
+			maxD = len([]rune(nq)) / 4
+			if maxD < 1 {
+				maxD = 1
+			}
+		}
+		fuzzyCap := limit + offset
+
+		appendFuzzy := func(root *bkNode, collect func(bkMatch) (key string, item SearchItem)) {
+			if root == nil || len(fuzzyItems) >= fuzzyCap {
+				return
+			}
+			var raw []bkMatch
+			root.search(nq, maxD, &raw)
+			sort.Slice(raw, func(i, j int) bool { return raw[i].distance < raw[j].distance })
+			for _, m := range raw {
+				if len(fuzzyItems) >= fuzzyCap {
+					break
+				}
+				key, item := collect(m)
+				if matched[key] {
+					continue
+				}
+				matched[key] = true
+				fuzzyItems = append(fuzzyItems, item)
+			}
+		}
+
+		withExplain := func(item SearchItem, distance int) SearchItem {
+			if explain {
+				item.Explain = &SearchExplain{MatchType: "fuzzy", Distance: distance}
+			}
+			return item
+		}
+
+		if includeDes {
+			appendFuzzy(idx.DesaBK, func(m bkMatch) (string, SearchItem) {
+				e := idx.Desa[m.idx]
+				item := SearchItem{Type: "desa", Label: e.Label, Distance: m.distance}
+				item.IDs.Pro, item.IDs.Kab, item.IDs.Kec, item.IDs.Des = e.Pro, e.Kab, e.Kec, e.Des
+				return "desa:" + e.Pro + e.Kab + e.Kec + e.Des, withExplain(item, m.distance)
+			})
+		}
+		if includeKec {
+			appendFuzzy(idx.KecamatanBK, func(m bkMatch) (string, SearchItem) {
+				e := idx.Kecamatan[m.idx]
+				item := SearchItem{Type: "kecamatan", Label: e.Label, Distance: m.distance}
+				item.IDs.Pro, item.IDs.Kab, item.IDs.Kec = e.Pro, e.Kab, e.Kec
+				return "kecamatan:" + e.Pro + e.Kab + e.Kec, withExplain(item, m.distance)
+			})
+		}
+		if includeKab {
+			appendFuzzy(idx.KabupatenBK, func(m bkMatch) (string, SearchItem) {
+				e := idx.Kabupaten[m.idx]
+				item := SearchItem{Type: "kabupaten", Label: e.Label, Distance: m.distance}
+				item.IDs.Pro, item.IDs.Kab = e.Pro, e.Kab
+				return "kabupaten:" + e.Pro + e.Kab, withExplain(item, m.distance)
+			})
+		}
+		if includePro {
+			appendFuzzy(idx.ProvinsiBK, func(m bkMatch) (string, SearchItem) {
+				e := idx.Provinsi[m.idx]
+				item := SearchItem{Type: "provinsi", Label: e.Label, Distance: m.distance}
+				item.IDs.Pro = e.Pro
+				return "provinsi:" + e.Pro, withExplain(item, m.distance)
+			})
 		}
 	}
 
@@ -1060,197 +2676,1063 @@ func searchWilayah(c *fiber.Ctx) error {
 		labels[i] = it.Label
 	}
 
-	return c.JSON(SearchResponse{Query: q, Count: total, Offset: offset, Limit: limit, Results: labels, Items: page})
+	return SearchResponse{Query: q, Count: total, Offset: offset, Limit: limit, Results: labels, Items: page, Partial: partial}
 }
 
-// startScraper godoc
-// @Summary      Start scraper
-// @Description  Start the data scraping process with specified number of threads
-// @Tags         scraper
+// suggestWilayah godoc
+// @Summary      Saran alamat (autocomplete bergaya DaData)
+// @Description  Diberikan teks parsial, kembalikan kandidat wilayah dengan breadcrumb hierarki lengkap (desa→kecamatan→kabupaten→provinsi) dan kode gabungan pro+kab+kec+des. Query dinormalisasi: prefiks administratif (Kab./Kabupaten/Kec./...) dihapus dan alias nama (mis. "Jogja" -> "Daerah Istimewa Yogyakarta") diterapkan sebelum pencocokan. Kandidat ditemukan lewat trie-prefix/parent-prefix/substring lalu diberi skor hybrid (bonus prefix, overlap token, kemiripan Damerau-Levenshtein); ties dipecah berdasarkan nama terpendek lalu kode administratif.
+// @Tags         search
 // @Accept       json
 // @Produce      json
-// @Param        threads    query   int     false  "Number of threads (1-10, default 4)" example(6)
-// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
-// @Success      200        {object}  ScraperStartResponse "Scraper started successfully"
-// @Failure      400        {object}  ErrorResponse
-// @Failure      401        {object}  ErrorResponse "API key required"
-// @Failure      403        {object}  ErrorResponse "Invalid API key"
-// @Router       /scraper/start [post]
-// @Security     ApiKeyAuth
-func startScraper(c *fiber.Ctx) error {
-	if globalScraper.IsRunning() {
-		return c.Status(400).JSON(fiber.Map{
-			"error": "Scraper is already running",
-		})
+// @Param        q      query     string  true  "Teks alamat parsial (case-insensitive)" example(bentng)
+// @Param        limit  query     int     false "Batas jumlah kandidat (1-50, default 10)" example(10)
+// @Param        level  query     string  false "Batasi level: desa|kecamatan|kabupaten|provinsi" example(kabupaten)
+// @Param        parent query     string  false "Batasi ke keturunan kode provinsi(2)/kabupaten(4)/kecamatan(7) ini" example(7302)
+// @Success      200    {object}  SuggestResponse
+// @Failure      400    {object}  ErrorResponse
+// @Router       /suggest [get]
+func suggestWilayah(c *fiber.Ctx) error {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Parameter 'q' wajib diisi"})
 	}
 
-	threads := c.QueryInt("threads", 4)
-	if threads < 1 || threads > 10 {
-		threads = 4
+	limit := c.QueryInt("limit", 10)
+	parent := strings.TrimSpace(c.Query("parent"))
+	level := strings.ToLower(strings.TrimSpace(c.Query("level")))
+
+	return c.JSON(runSuggest(q, limit, parent, level))
+}
+
+// runSuggest is the fiber.Ctx-independent core of suggestWilayah, shared
+// with the NATS wilayah.suggest subject so both transports rank candidates
+// through the exact same trie/parent-prefix/substring pipeline and hybrid
+// score.
+func runSuggest(q string, limit int, parent string, level string) SuggestResponse {
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+	level = strings.ToLower(strings.TrimSpace(level))
+
+	var parentPro, parentKab, parentKec string
+	switch len(parent) {
+	case 2:
+		parentPro = parent
+	case 4:
+		parentPro, parentKab = parent[:2], parent[2:]
+	case 7:
+		parentPro, parentKab, parentKec = parent[:2], parent[2:4], parent[4:]
 	}
+	hasParentFilter := parentPro != ""
 
-	// Create new scraper instance with specified threads
-	globalScraper = scraper.NewScraper(scraper.ScraperConfig{
-		MaxWorkers: threads,
-		OutputDir:  "scraper/output",
-	})
+	includeDes := level == "" || level == "desa"
+	includeKec := level == "" || level == "kecamatan"
+	includeKab := level == "" || level == "kabupaten"
+	includePro := level == "" || level == "provinsi"
 
-	// Start scraping in background
-	go func() {
-		if err := globalScraper.ScrapeAll(); err != nil {
-			log.Printf("‚ùå Scraper error: %v", err)
-		}
-	}()
+	idx := ensureSearchIndex()
 
-	return c.JSON(fiber.Map{
-		"message": "Scraper started successfully",
-		"threads": threads,
-		"status":  "running",
-	})
-}
+	nq := normalizeSuggestQuery(q)
+	qTokens := nameTokens(nq)
 
-// stopScraper godoc
-// @Summary      Stop scraper
-// @Description  Stop the data scraping process gracefully
-// @Tags         scraper
-// @Accept       json
-// @Produce      json
-// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
-// @Success      200        {object}  ScraperStopResponse "Scraper stopped successfully"
-// @Router       /scraper/stop [post]
-// @Security     ApiKeyAuth
-func stopScraper(c *fiber.Ctx) error {
-	if !globalScraper.IsRunning() {
-		return c.JSON(fiber.Map{
-			"message": "Scraper is not running",
-			"status":  "stopped",
-		})
+	type candidate struct {
+		item     SuggestItem
+		tier     int // 0 = leaf prefix, 1 = parent prefix, 2 = substring
+		nameNorm string
 	}
+	var candidates []candidate
+	seen := make(map[string]bool)
 
-	globalScraper.Stop()
-
-	return c.JSON(fiber.Map{
-		"message": "Scraper stop signal sent",
-		"status":  "stopping",
-	})
-}
+	toItem := func(typ, pro, kab, kec, des, label string) SuggestItem {
+		item := SuggestItem{Type: typ, Code: pro + kab + kec + des, Label: label, Breadcrumb: strings.Split(label, ", ")}
+		item.IDs.Pro, item.IDs.Kab, item.IDs.Kec, item.IDs.Des = pro, kab, kec, des
+		return item
+	}
+	addCandidate := func(tier int, typ, pro, kab, kec, des, label, nameNorm string) {
+		key := typ + ":" + pro + kab + kec + des
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		candidates = append(candidates, candidate{item: toItem(typ, pro, kab, kec, des, label), tier: tier, nameNorm: nameNorm})
+	}
 
-// getScraperStatus godoc
-// @Summary      Get scraper status
-// @Description  Get the current status of the scraper (running/stopped)
-// @Tags         scraper
-// @Accept       json
-// @Produce      json
-// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
-// @Success      200        {object}  ScraperStatusResponse "Scraper status information"
-// @Failure      401        {object}  ErrorResponse "API key required"
-// @Failure      403        {object}  ErrorResponse "Invalid API key"
+	// Leaf-prefix tier: O(len(q)) trie lookups instead of scanning every
+	// entry. Provinsi is never a descendant of anything, so it's skipped
+	// below once any parent filter is set.
+	if includeDes {
+		if parentKec == "" {
+			for _, i := range idx.DesaTrie.prefixSearch(nq) {
+				e := idx.Desa[i]
+				if hasParentFilter && !(e.Pro == parentPro && (parentKab == "" || e.Kab == parentKab)) {
+					continue
+				}
+				addCandidate(0, "desa", e.Pro, e.Kab, e.Kec, e.Des, e.Label, e.NameNorm)
+			}
+		} else {
+			for _, i := range idx.DesaTrie.prefixSearch(nq) {
+				e := idx.Desa[i]
+				if e.Pro == parentPro && e.Kab == parentKab && e.Kec == parentKec {
+					addCandidate(0, "desa", e.Pro, e.Kab, e.Kec, e.Des, e.Label, e.NameNorm)
+				}
+			}
+		}
+	}
+	if includeKec {
+		for _, i := range idx.KecamatanTrie.prefixSearch(nq) {
+			e := idx.Kecamatan[i]
+			if hasParentFilter {
+				if e.Pro != parentPro || (parentKab != "" && e.Kab != parentKab) || parentKec != "" {
+					continue
+				}
+			}
+			addCandidate(0, "kecamatan", e.Pro, e.Kab, e.Kec, "", e.Label, e.NameNorm)
+		}
+	}
+	if includeKab {
+		for _, i := range idx.KabupatenTrie.prefixSearch(nq) {
+			e := idx.Kabupaten[i]
+			if hasParentFilter {
+				if e.Pro != parentPro || parentKab != "" {
+					continue
+				}
+			}
+			addCandidate(0, "kabupaten", e.Pro, e.Kab, "", "", e.Label, e.NameNorm)
+		}
+	}
+	if includePro && !hasParentFilter {
+		for _, i := range idx.ProvinsiTrie.prefixSearch(nq) {
+			e := idx.Provinsi[i]
+			addCandidate(0, "provinsi", e.Pro, "", "", "", e.Label, e.NameNorm)
+		}
+	}
+
+	// Parent-prefix and substring tiers only need to run a full scan when the
+	// leaf-prefix tier above hasn't already filled the page; this keeps the
+	// common case (a good leaf prefix) on the fast, trie-only path.
+	if len(candidates) < limit {
+		matchParent := func(norms []string) bool {
+			for _, pn := range norms {
+				if strings.HasPrefix(pn, nq) {
+					return true
+				}
+			}
+			return false
+		}
+		containsAny := func(nameNorm string, norms []string) bool {
+			if strings.Contains(nameNorm, nq) {
+				return true
+			}
+			for _, pn := range norms {
+				if strings.Contains(pn, nq) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if includeDes {
+			for _, e := range idx.Desa {
+				if hasParentFilter {
+					if e.Pro != parentPro || (parentKab != "" && e.Kab != parentKab) || (parentKec != "" && e.Kec != parentKec) {
+						continue
+					}
+				}
+				if seen["desa:"+e.Pro+e.Kab+e.Kec+e.Des] {
+					continue
+				}
+				if matchParent(e.ParentNorms) {
+					addCandidate(1, "desa", e.Pro, e.Kab, e.Kec, e.Des, e.Label, e.NameNorm)
+				} else if containsAny(e.NameNorm, e.ParentNorms) {
+					addCandidate(2, "desa", e.Pro, e.Kab, e.Kec, e.Des, e.Label, e.NameNorm)
+				}
+			}
+		}
+		if includeKec {
+			for _, e := range idx.Kecamatan {
+				if hasParentFilter {
+					if e.Pro != parentPro || (parentKab != "" && e.Kab != parentKab) || parentKec != "" {
+						continue
+					}
+				}
+				if seen["kecamatan:"+e.Pro+e.Kab+e.Kec] {
+					continue
+				}
+				if matchParent(e.ParentNorms) {
+					addCandidate(1, "kecamatan", e.Pro, e.Kab, e.Kec, "", e.Label, e.NameNorm)
+				} else if containsAny(e.NameNorm, e.ParentNorms) {
+					addCandidate(2, "kecamatan", e.Pro, e.Kab, e.Kec, "", e.Label, e.NameNorm)
+				}
+			}
+		}
+		if includeKab {
+			for _, e := range idx.Kabupaten {
+				if hasParentFilter {
+					if e.Pro != parentPro || parentKab != "" {
+						continue
+					}
+				}
+				if seen["kabupaten:"+e.Pro+e.Kab] {
+					continue
+				}
+				if matchParent(e.ParentNorms) {
+					addCandidate(1, "kabupaten", e.Pro, e.Kab, "", "", e.Label, e.NameNorm)
+				} else if containsAny(e.NameNorm, e.ParentNorms) {
+					addCandidate(2, "kabupaten", e.Pro, e.Kab, "", "", e.Label, e.NameNorm)
+				}
+			}
+		}
+		if includePro && !hasParentFilter {
+			for _, e := range idx.Provinsi {
+				if seen["provinsi:"+e.Pro] {
+					continue
+				}
+				if strings.Contains(e.NameNorm, nq) {
+					addCandidate(2, "provinsi", e.Pro, "", "", "", e.Label, e.NameNorm)
+				}
+			}
+		}
+	}
+
+	for i := range candidates {
+		cnd := &candidates[i]
+		cnd.item.Score = suggestHybridScore(nq, cnd.nameNorm, cnd.tier, qTokens, nameTokens(suggestOwnName(cnd.item.Label)))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.item.Score != b.item.Score {
+			return a.item.Score > b.item.Score
+		}
+		// Tie-break: shorter name first, then by administrative code.
+		if len(a.nameNorm) != len(b.nameNorm) {
+			return len(a.nameNorm) < len(b.nameNorm)
+		}
+		return a.item.Code < b.item.Code
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	items := make([]SuggestItem, len(candidates))
+	for i, cnd := range candidates {
+		items[i] = cnd.item
+	}
+
+	return SuggestResponse{Query: q, Count: len(items), Items: items}
+}
+
+// parseAddressWilayah godoc
+// @Summary      Parse alamat bebas teks menjadi kode wilayah
+// @Description  Menerima alamat mentah (gaya DaData /clean), lalu mengembalikan kandidat {pro, kab, kec, des} terbaik beserta label ternormalisasi dan skor confidence 0..1. Segmen dipisah berdasarkan koma, prefiks umum (kel./kec./kab./kota/prov.) dihapus, alias provinsi (mis. SULSEL, DKI) diterapkan, lalu dicocokkan top-down lewat hierarki wilayah memakai normalizeName dan fallback Levenshtein. Jika confidence di bawah ambang, beberapa kandidat teratas dikembalikan untuk disambiguasi.
+// @Tags         search
+// @Accept       json
+// @Produce      json
+// @Param        request body      ParseRequest  true  "Alamat mentah"
+// @Success      200      {object}  ParseResponse
+// @Failure      400      {object}  ErrorResponse
+// @Router       /parse [post]
+func parseAddressWilayah(c *fiber.Ctx) error {
+	var req ParseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Body tidak valid, kirim JSON {\"address\": \"...\"}"})
+	}
+	address := strings.TrimSpace(req.Address)
+	if address == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "Field 'address' wajib diisi"})
+	}
+
+	candidates := parseAddress(address)
+	confidence := 0.0
+	if len(candidates) > 0 {
+		confidence = candidates[0].Confidence
+	}
+
+	return c.JSON(ParseResponse{Query: address, Confidence: confidence, Candidates: candidates})
+}
+
+// startScraper godoc
+// @Summary      Start scraper
+// @Description  Start the data scraping process with specified number of threads
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        threads    query   int     false  "Number of threads (1-10, default 4)" example(6)
+// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
+// @Success      200        {object}  ScraperStartResponse "Scraper started successfully"
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Router       /scraper/start [post]
+// @Security     ApiKeyAuth
+func startScraper(c *fiber.Ctx) error {
+	if _, sc, ok := globalJobManager.Latest(); ok && sc != nil && sc.IsRunning() {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Scraper is already running",
+		})
+	}
+
+	threads := c.QueryInt("threads", 4)
+	if threads < 1 || threads > 10 {
+		threads = 4
+	}
+
+	// Back-compat shim: submit a full, unscoped job through the job
+	// manager and report it the way the old single-scraper endpoint did.
+	id, err := globalJobManager.Submit(scraper.JobSpec{Workers: threads})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Scraper started successfully",
+		"threads": threads,
+		"status":  "running",
+		"job_id":  id,
+	})
+}
+
+// stopScraper godoc
+// @Summary      Stop scraper
+// @Description  Stop the data scraping process gracefully
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
+// @Success      200        {object}  ScraperStopResponse "Scraper stopped successfully"
+// @Router       /scraper/stop [post]
+// @Security     ApiKeyAuth
+func stopScraper(c *fiber.Ctx) error {
+	rec, sc, ok := globalJobManager.Latest()
+	if !ok || sc == nil || !sc.IsRunning() {
+		return c.JSON(fiber.Map{
+			"message": "Scraper is not running",
+			"status":  "stopped",
+		})
+	}
+
+	globalJobManager.Cancel(rec.ID)
+
+	return c.JSON(fiber.Map{
+		"message": "Scraper stop signal sent",
+		"status":  "stopping",
+	})
+}
+
+// getScraperStatus godoc
+// @Summary      Get scraper status
+// @Description  Get the current status of the scraper (running/stopped)
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
+// @Success      200        {object}  ScraperStatusResponse "Scraper status information"
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
 // @Router       /scraper/status [get]
 // @Security     ApiKeyAuth
 func getScraperStatus(c *fiber.Ctx) error {
-	isRunning := globalScraper.IsRunning()
+	_, sc, ok := globalJobManager.Latest()
+	isRunning := ok && sc != nil && sc.IsRunning()
 	status := "stopped"
 	if isRunning {
 		status = "running"
 	}
 
-	return c.JSON(fiber.Map{
-		"status":  status,
-		"running": isRunning,
-	})
+	return c.JSON(fiber.Map{
+		"status":  status,
+		"running": isRunning,
+	})
+}
+
+// getScraperProgress godoc
+// @Summary      Get scraper progress
+// @Description  Get the current progress of the scraping process with detailed statistics. Pass ?format=openmetrics to get the same counters as OpenMetrics text instead of JSON.
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
+// @Param        format     query   string  false  "Set to openmetrics to render counters as OpenMetrics text"
+// @Success      200        {object}  ScraperProgressResponse "Scraping progress with statistics"
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Router       /scraper/progress [get]
+// @Security     ApiKeyAuth
+func getScraperProgress(c *fiber.Ctx) error {
+	_, sc, ok := globalJobManager.Latest()
+	if !ok || sc == nil {
+		if strings.EqualFold(c.Query("format"), "openmetrics") {
+			c.Set(fiber.HeaderContentType, "application/openmetrics-text; version=1.0.0; charset=utf-8")
+			return c.SendString("")
+		}
+		return c.JSON(fiber.Map{"provinces": 0, "kabupaten": 0, "kecamatan": 0, "desa": 0, "running": false})
+	}
+
+	if strings.EqualFold(c.Query("format"), "openmetrics") {
+		c.Set(fiber.HeaderContentType, "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		return c.SendString(sc.GetProgressOpenMetrics())
+	}
+	progress := sc.GetProgress()
+	return c.JSON(progress)
+}
+
+// getScraperEvents godoc
+// @Summary      Stream scraper events
+// @Description  Server-Sent Events stream of scraper lifecycle events (started, progress, stopped, error, finished). Send a Last-Event-ID header to resume from the last delivered event instead of only seeing events published after connecting.
+// @Tags         scraper
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        X-API-Key      header  string  true   "API Key for authentication" example(your_api_key_here)
+// @Param        Last-Event-ID  header  string  false  "ID of the last event this client saw, to resume a dropped connection"
+// @Success      200        "text/event-stream of scraper events"
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Router       /scraper/events [get]
+// @Security     ApiKeyAuth
+func getScraperEvents(c *fiber.Ctx) error {
+	_, sc, ok := globalJobManager.Latest()
+	if !ok || sc == nil {
+		return c.Status(404).JSON(fiber.Map{"error": "no scraper job to stream events from"})
+	}
+
+	var lastEventID int64
+	if v := c.Get("Last-Event-ID"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			lastEventID = n
+		}
+	}
+
+	events, unsubscribe := sc.Subscribe(lastEventID)
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		done := c.Context().Done()
+		for {
+			select {
+			case <-done:
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				data, _ := json.Marshal(ev.Data)
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+
+	return nil
+}
+
+// postScraperJob godoc
+// @Summary      Submit a scraper job
+// @Description  Start a new, independently tracked scrape job, optionally scoped to one or more provinsi (only) or kabupaten (kab_only). Runs immediately in the background; poll GET /scraper/jobs/{id} for status.
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string             true   "API Key for authentication" example(your_api_key_here)
+// @Param        body       body    ScraperJobRequest  false  "Job configuration"
+// @Success      201        {object}  ScraperJobResponse "Job accepted"
+// @Failure      400        {object}  ErrorResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Router       /scraper/jobs [post]
+// @Security     ApiKeyAuth
+func postScraperJob(c *fiber.Ctx) error {
+	var req ScraperJobRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+		}
+	}
+	if req.Workers < 1 || req.Workers > 10 {
+		req.Workers = 4
+	}
+
+	id, err := globalJobManager.Submit(scraper.JobSpec{
+		Workers:  req.Workers,
+		Only:     req.Only,
+		KabOnly:  req.KabOnly,
+		Exclude:  req.Exclude,
+		Priority: req.Priority,
+		CronExpr: req.CronExpr,
+		RuleSet:  req.RuleSet,
+		DryRun:   req.DryRun,
+	})
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	rec, _ := globalJobManager.Get(id)
+	return c.Status(201).JSON(rec)
+}
+
+// listScraperJobs godoc
+// @Summary      List scraper jobs
+// @Description  List every scrape job this process knows about, oldest first
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string  true  "API Key for authentication" example(your_api_key_here)
+// @Success      200        {array}   ScraperJobResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Router       /scraper/jobs [get]
+// @Security     ApiKeyAuth
+func listScraperJobs(c *fiber.Ctx) error {
+	return c.JSON(globalJobManager.List())
+}
+
+// getScraperJob godoc
+// @Summary      Get a scraper job
+// @Description  Get one scrape job's record by ID
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        id         path    string  true  "Job ID" example(job_1a2b3c4d5e6f7890)
+// @Param        X-API-Key  header  string  true  "API Key for authentication" example(your_api_key_here)
+// @Success      200        {object}  ScraperJobResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Failure      404        {object}  ErrorResponse "Job not found"
+// @Router       /scraper/jobs/{id} [get]
+// @Security     ApiKeyAuth
+func getScraperJob(c *fiber.Ctx) error {
+	rec, ok := globalJobManager.Get(scraper.JobID(c.Params("id")))
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "job not found"})
+	}
+	return c.JSON(rec)
+}
+
+// deleteScraperJob godoc
+// @Summary      Cancel a scraper job
+// @Description  Stop a running scrape job; has no effect on a job that already finished or that was loaded from a previous process
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        id         path    string  true  "Job ID" example(job_1a2b3c4d5e6f7890)
+// @Param        X-API-Key  header  string  true  "API Key for authentication" example(your_api_key_here)
+// @Success      200        {object}  ScraperJobStopResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Failure      404        {object}  ErrorResponse "Job not found"
+// @Router       /scraper/jobs/{id} [delete]
+// @Security     ApiKeyAuth
+func deleteScraperJob(c *fiber.Ctx) error {
+	id := scraper.JobID(c.Params("id"))
+	if err := globalJobManager.Cancel(id); err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"message": "stop signal sent", "job_id": id})
+}
+
+// listScraperRules godoc
+// @Summary      List loaded rule sets
+// @Description  List every extraction rule set currently loaded from scraper/rules/*.yaml
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string  true  "API Key for authentication" example(your_api_key_here)
+// @Success      200        {array}   RuleSetResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Router       /scraper/rules [get]
+// @Security     ApiKeyAuth
+func listScraperRules(c *fiber.Ctx) error {
+	rm := globalJobManager.Rules()
+	if rm == nil {
+		return c.JSON([]rules.RuleSet{})
+	}
+	sets := rm.List()
+	out := make([]rules.RuleSet, 0, len(sets))
+	for _, rs := range sets {
+		out = append(out, *rs)
+	}
+	return c.JSON(out)
+}
+
+// reloadScraperRules godoc
+// @Summary      Reload rule sets
+// @Description  Re-read every scraper/rules/*.yaml file without restarting the process, so an operator can edit or add a rule set for a new upstream source on the fly
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Param        X-API-Key  header  string  true  "API Key for authentication" example(your_api_key_here)
+// @Success      200        {object}  RulesReloadResponse
+// @Failure      401        {object}  ErrorResponse "API key required"
+// @Failure      403        {object}  ErrorResponse "Invalid API key"
+// @Failure      500        {object}  ErrorResponse
+// @Router       /scraper/rules/reload [post]
+// @Security     ApiKeyAuth
+func reloadScraperRules(c *fiber.Ctx) error {
+	rm := globalJobManager.Rules()
+	if rm == nil {
+		return c.Status(500).JSON(fiber.Map{"error": "no rules manager configured"})
+	}
+	if err := rm.Reload(); err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(fiber.Map{
+		"message": "rule sets reloaded",
+		"count":   len(rm.List()),
+	})
+}
+
+// getAPIInfo godoc
+// @Summary      Get API key info
+// @Description  Get information about API key requirement for scraper control endpoints
+// @Tags         scraper
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ScraperInfoResponse "API key information and usage examples"
+// @Router       /scraper/info [get]
+func getAPIInfo(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"message":          "Scraper control endpoints require API key authentication",
+		"api_key_required": apiKey != "",
+		"methods": fiber.Map{
+			"header": "X-API-Key: your_api_key",
+			"query":  "?api_key=your_api_key",
+			"curl_example": fmt.Sprintf("curl -H \"X-API-Key: %s\" http://localhost:%s/api/v1/scraper/status",
+				func() string {
+					if apiKey != "" {
+						return "YOUR_API_KEY"
+					}
+					return "NOT_REQUIRED"
+				}(), c.Get("Host")),
+		},
+	})
+}
+
+func main() {
+	// Parse command line arguments
+	if len(os.Args) < 2 {
+		// Default behavior: run API
+		runAPI("3000")
+		return
+	}
+
+	command := strings.ToLower(os.Args[1])
+
+	switch command {
+	case "api":
+		port := "3000"
+		if len(os.Args) > 2 {
+			port = os.Args[2]
+		}
+		runAPI(port)
+
+	case "scrape":
+		configPath := configFlagValue(os.Args[2:], "wilayah.yml")
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			fmt.Printf("❌ Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+
+		profileName := profileFlagValue(os.Args[2:])
+		if profileName != "" {
+			cfg, err = config.ApplyProfile(cfg, profileName)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		fs := flag.NewFlagSet("scrape", flag.ExitOnError)
+		fs.String("config", configPath, "path to wilayah.yml or scraper.toml config file")
+		fs.String("profile", profileName, "named profile from --config's profiles.<name> to use, overriding rps/retries/backoff/year/format/only/exclude")
+		rps := fs.Float64("rps", cfg.RateLimit, "max requests/sec shared across all workers")
+		maxRetries := fs.Int("max-retries", cfg.Retries, "HTTP retry attempts on 429/5xx/network errors")
+		backoffBase := fs.Duration("backoff-base", cfg.BackoffBase, "base delay for exponential backoff between retries")
+		format := fs.String("format", strings.Join(cfg.Output.Formats, ","), "comma-separated output formats for the final file: json, ndjson, csv, sql, or sqlite (e.g. ndjson,sqlite)")
+		metricsAddr := fs.String("metrics-addr", "", "if set, serve Prometheus metrics on this address (e.g. :9090)")
+		incremental := fs.Bool("incremental", false, "skip re-walking kabupaten whose kecamatan list hash hasn't changed since the last run")
+		years := fs.String("year", "", "comma-separated years to scrape, overriding wilayah.yml, e.g. 2023,2024,2025")
+		showProgress := fs.Bool("progress", false, "render a live, single-line progress bar instead of one log line per event")
+		noProgress := fs.Bool("no-progress", false, "force plain one-line-per-event logging (default)")
+		silent := fs.Bool("silent", false, "suppress all scrape progress output")
+		providerKind := fs.String("provider", "sipedas", "data source to walk: sipedas (live API, default), seed (offline wilayah_final_*.json via --seed-file), or merge (sipedas reconciled against --seed-file)")
+		seedFile := fs.String("seed-file", "", "wilayah_final_*.json snapshot backing --provider=seed or --provider=merge")
+		rulesDirFlag := fs.String("rules-dir", "scraper/rules", "directory of *.yaml rule sets for the rule-set subcommand")
+		dryRun := fs.Bool("dry-run", false, "with the rule-set subcommand, print the first captures of the run (not grouped by rule) instead of saving output")
+		fs.Parse(os.Args[2:])
+
+		progressMode := progress.ParseMode(*showProgress, *noProgress, *silent)
+
+		if *metricsAddr != "" {
+			metrics.Serve(*metricsAddr)
+		}
+
+		args := fs.Args()
+		if len(args) > 0 && args[0] == "info" {
+			runScraperInfo()
+			return
+		}
+		if len(args) > 0 && args[0] == "clean" {
+			days := 7
+			if len(args) > 1 {
+				if d, err := strconv.Atoi(args[1]); err == nil {
+					days = d
+				}
+			}
+			runScraperClean(days)
+			return
+		}
+		if len(args) > 0 && args[0] == "diff" {
+			if len(args) < 3 {
+				fmt.Println("❌ Format: go run main.go scrape diff <old.json> <new.json>")
+				os.Exit(1)
+			}
+			runDiff(args[1], args[2])
+			return
+		}
+		if len(args) > 0 && args[0] == "rule-set" {
+			if len(args) < 2 {
+				fmt.Println("❌ Format: go run main.go scrape rule-set <name> [--rules-dir scraper/rules] [--dry-run]")
+				os.Exit(1)
+			}
+			runScraperRuleSet(*rulesDirFlag, args[1], *dryRun)
+			return
+		}
+		if len(args) > 0 && args[0] == "watch" {
+			src := cfg.Sources[0]
+			year := time.Now().Year()
+			if len(src.Years) > 0 {
+				year = src.Years[0]
+			}
+			runScraperWatch(src.BaseURL, year, cfg.Output.Dir, cfg.Workers, *rps, *maxRetries, *backoffBase, progressMode, *providerKind, *seedFile, cfg.Only, cfg.Exclude)
+			return
+		}
+
+		maxWorkers := cfg.Workers
+		if len(args) > 0 {
+			if w, err := strconv.Atoi(args[0]); err == nil && w > 0 && w <= 10 {
+				maxWorkers = w
+			}
+		}
+
+		sources := cfg.Sources
+		if *years != "" {
+			sources = applyYearOverride(sources, *years)
+		}
+
+		for _, src := range sources {
+			for _, year := range src.Years {
+				outputDir := cfg.Output.Dir
+				if len(sources) > 1 || len(src.Years) > 1 {
+					outputDir = filepath.Join(cfg.Output.Dir, src.Name, strconv.Itoa(year))
+				}
+
+				if *incremental {
+					runScraperIncremental(src.BaseURL, year, outputDir, maxWorkers, *rps, *maxRetries, *backoffBase, progressMode, *providerKind, *seedFile, cfg.Only, cfg.Exclude)
+					continue
+				}
+				runScraper(src.BaseURL, year, outputDir, maxWorkers, *rps, *maxRetries, *backoffBase, *format, progressMode, *providerKind, *seedFile, cfg.Only, cfg.Exclude)
+			}
+		}
+
+	case "diff":
+		if len(os.Args) < 4 {
+			fmt.Println("❌ Format: go run main.go diff <old_wilayah_final.json> <new_wilayah_final.json>")
+			os.Exit(1)
+		}
+		runDiff(os.Args[2], os.Args[3])
+
+	case "convert":
+		if len(os.Args) < 3 {
+			fmt.Println("❌ Format: go run main.go convert <input_file> [--format json|ndjson|csv|sql|sqlite] [output_file]")
+			os.Exit(1)
+		}
+		fs := flag.NewFlagSet("convert", flag.ExitOnError)
+		format := fs.String("format", "json", "output format: json, ndjson, csv, sql, or sqlite")
+		fs.Parse(os.Args[3:])
+
+		inputFile := os.Args[2]
+		outputFile := ""
+		if args := fs.Args(); len(args) > 0 {
+			outputFile = args[0]
+		}
+		runConvert(inputFile, outputFile, *format)
+
+	case "help", "--help", "-h":
+		scraper.ShowHelp()
+
+	default:
+		fmt.Printf("‚ùå Perintah tidak dikenal: %s\n", command)
+		fmt.Println("Gunakan 'help' untuk melihat perintah yang tersedia.")
+		scraper.ShowHelp()
+	}
+}
+
+// natsConn is the optional NATS request/reply transport. It stays nil unless
+// NATS_URL is set, in which case it mirrors the HTTP API so other services
+// in a NATS mesh can resolve wilayah codes without an HTTP round trip.
+var natsConn *nats.Conn
+
+// natsSubjects maps each mirrored subject to its handler. Every handler
+// reuses the same wilayahStore/searchIndex code paths as the Fiber routes,
+// so the two transports can never drift. These are the original,
+// unversioned subjects, kept as-is for existing subscribers; new
+// integrations should prefer natsV1Subjects below.
+var natsSubjects = map[string]nats.MsgHandler{
+	"wilayah.provinsi.list":        natsHandleProvinsiList,
+	"wilayah.kabupaten.byProvince": natsHandleKabupatenByProvince,
+	"wilayah.info.byCode":          natsHandleInfoByCode,
+	"wilayah.search":               natsHandleSearch,
+	"wilayah.suggest":              natsHandleSuggest,
+}
+
+// natsV1Subjects are the versioned, wilayahService-backed subjects other
+// NATS-native organizations in our mesh expect: a stable "wilayah.v1.*"
+// namespace replying with the {data, error} envelope (see v1Respond)
+// instead of the legacy subjects' bare success body / {"error": ...}
+// shape. client.Client talks to these.
+var natsV1Subjects = map[string]nats.MsgHandler{
+	"wilayah.v1.info":           natsV1HandleInfo,
+	"wilayah.v1.provinsi.list":  natsV1HandleProvinsiList,
+	"wilayah.v1.kabupaten.list": natsV1HandleKabupatenList,
+	"wilayah.v1.lookup.batch":   natsV1HandleLookupBatch,
+	"wilayah.v1.health":         natsV1HandleHealth,
+}
+
+// natsRespond marshals v to JSON and replies on msg. Marshal failures are
+// logged rather than retried since v is always one of our own response
+// structs/maps.
+func natsRespond(msg *nats.Msg, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("nats: failed to marshal response for %s: %v", msg.Subject, err)
+		return
+	}
+	if err := msg.Respond(data); err != nil {
+		log.Printf("nats: failed to respond on %s: %v", msg.Subject, err)
+	}
+}
+
+func natsRespondError(msg *nats.Msg, errMsg string) {
+	natsRespond(msg, fiber.Map{"error": errMsg})
+}
+
+// natsHandleProvinsiList mirrors GET /api/v1/provinsi: no payload required.
+func natsHandleProvinsiList(msg *nats.Msg) {
+	refs := loadService().Provinsi()
+	response := make([]ProvinsiResponse, len(refs))
+	for i, r := range refs {
+		response[i] = ProvinsiResponse{ID: r.ID, Nama: r.Nama}
+	}
+	natsRespond(msg, response)
+}
+
+// natsHandleKabupatenByProvince mirrors GET /api/v1/kabupaten?pro=...
+func natsHandleKabupatenByProvince(msg *nats.Msg) {
+	var req struct {
+		Pro string `json:"pro"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.Pro == "" {
+		natsRespondError(msg, "Parameter 'pro' is required")
+		return
+	}
+
+	kab, ok := loadService().KabupatenByProvince(req.Pro)
+	if !ok {
+		natsRespondError(msg, "Province not found")
+		return
+	}
+
+	response := make([]KabupatenResponse, len(kab))
+	for i, r := range kab {
+		response[i] = KabupatenResponse{ID: r.ID, Nama: r.Nama}
+	}
+	natsRespond(msg, response)
+}
+
+// natsHandleInfoByCode mirrors GET /api/v1/info/{code} via resolveWilayahInfo.
+func natsHandleInfoByCode(msg *nats.Msg) {
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.Code == "" {
+		natsRespondError(msg, "Code parameter is required")
+		return
+	}
+
+	result, _, errMsg := resolveWilayahInfo(req.Code)
+	if errMsg != "" {
+		natsRespondError(msg, errMsg)
+		return
+	}
+	natsRespond(msg, result)
+}
+
+// natsHandleSearch mirrors GET /api/v1/search via runSearch.
+func natsHandleSearch(msg *nats.Msg) {
+	var req struct {
+		Q             string `json:"q"`
+		Limit         int    `json:"limit"`
+		Offset        int    `json:"offset"`
+		Level         string `json:"level"`
+		Fuzzy         bool   `json:"fuzzy"`
+		FuzzyDistance int    `json:"fuzzy_distance"`
+		Explain       bool   `json:"explain"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || strings.TrimSpace(req.Q) == "" {
+		natsRespondError(msg, "Parameter 'q' wajib diisi")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), searchMaxTimeout)
+	defer cancel()
+	natsRespond(msg, runSearch(ctx, req.Q, req.Limit, req.Offset, req.Level, req.Fuzzy, req.FuzzyDistance, req.Explain))
+}
+
+// natsHandleSuggest mirrors GET /api/v1/suggest via runSuggest.
+func natsHandleSuggest(msg *nats.Msg) {
+	var req struct {
+		Q      string `json:"q"`
+		Limit  int    `json:"limit"`
+		Parent string `json:"parent"`
+		Level  string `json:"level"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || strings.TrimSpace(req.Q) == "" {
+		natsRespondError(msg, "Parameter 'q' wajib diisi")
+		return
+	}
+	natsRespond(msg, runSuggest(req.Q, req.Limit, req.Parent, req.Level))
 }
 
-// getScraperProgress godoc
-// @Summary      Get scraper progress
-// @Description  Get the current progress of the scraping process with detailed statistics
-// @Tags         scraper
-// @Accept       json
-// @Produce      json
-// @Param        X-API-Key  header  string  true   "API Key for authentication" example(your_api_key_here)
-// @Success      200        {object}  ScraperProgressResponse "Scraping progress with statistics"
-// @Failure      401        {object}  ErrorResponse "API key required"
-// @Failure      403        {object}  ErrorResponse "Invalid API key"
-// @Router       /scraper/progress [get]
-// @Security     ApiKeyAuth
-func getScraperProgress(c *fiber.Ctx) error {
-	progress := globalScraper.GetProgress()
-	return c.JSON(progress)
+// v1Envelope is every wilayah.v1.* reply's shape: Data on success, Error on
+// failure, never both -- the {data, error} convention used by NATS-native
+// services in our mesh rather than the legacy subjects' bare body.
+type v1Envelope struct {
+	Data  interface{} `json:"data,omitempty"`
+	Error string      `json:"error,omitempty"`
 }
 
-// getAPIInfo godoc
-// @Summary      Get API key info
-// @Description  Get information about API key requirement for scraper control endpoints
-// @Tags         scraper
-// @Accept       json
-// @Produce      json
-// @Success      200  {object}  ScraperInfoResponse "API key information and usage examples"
-// @Router       /scraper/info [get]
-func getAPIInfo(c *fiber.Ctx) error {
-	return c.JSON(fiber.Map{
-		"message":          "Scraper control endpoints require API key authentication",
-		"api_key_required": apiKey != "",
-		"methods": fiber.Map{
-			"header": "X-API-Key: your_api_key",
-			"query":  "?api_key=your_api_key",
-			"curl_example": fmt.Sprintf("curl -H \"X-API-Key: %s\" http://localhost:%s/api/v1/scraper/status",
-				func() string {
-					if apiKey != "" {
-						return "YOUR_API_KEY"
-					}
-					return "NOT_REQUIRED"
-				}(), c.Get("Host")),
-		},
-	})
+func v1Respond(msg *nats.Msg, data interface{}) {
+	natsRespond(msg, v1Envelope{Data: data})
 }
 
-func main() {
-	// Parse command line arguments
-	if len(os.Args) < 2 {
-		// Default behavior: run API
-		runAPI("3000")
+func v1RespondError(msg *nats.Msg, errMsg string) {
+	natsRespond(msg, v1Envelope{Error: errMsg})
+}
+
+// natsV1HandleInfo mirrors GET /api/v1/info/{code} via wilayahService.Info,
+// replying with the v1Envelope convention.
+func natsV1HandleInfo(msg *nats.Msg) {
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.Code == "" {
+		v1RespondError(msg, "Code parameter is required")
 		return
 	}
+	result, _, errMsg := loadService().Info(req.Code)
+	if errMsg != "" {
+		v1RespondError(msg, errMsg)
+		return
+	}
+	v1Respond(msg, result)
+}
 
-	command := strings.ToLower(os.Args[1])
+// natsV1HandleProvinsiList mirrors GET /api/v1/provinsi: no payload required.
+func natsV1HandleProvinsiList(msg *nats.Msg) {
+	v1Respond(msg, loadService().Provinsi())
+}
 
-	switch command {
-	case "api":
-		port := "3000"
-		if len(os.Args) > 2 {
-			port = os.Args[2]
-		}
-		runAPI(port)
+// natsV1HandleKabupatenList mirrors GET /api/v1/kabupaten?pro=...
+func natsV1HandleKabupatenList(msg *nats.Msg) {
+	var req struct {
+		Pro string `json:"pro"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || req.Pro == "" {
+		v1RespondError(msg, "Parameter 'pro' is required")
+		return
+	}
+	kab, ok := loadService().KabupatenByProvince(req.Pro)
+	if !ok {
+		v1RespondError(msg, "Province not found")
+		return
+	}
+	v1Respond(msg, kab)
+}
 
-	case "scrape":
-		maxWorkers := 4
-		if len(os.Args) > 2 {
-			if os.Args[2] == "info" {
-				runScraperInfo()
-				return
-			}
-			if os.Args[2] == "clean" {
-				days := 7
-				if len(os.Args) > 3 {
-					if d, err := strconv.Atoi(os.Args[3]); err == nil {
-						days = d
-					}
-				}
-				runScraperClean(days)
-				return
-			}
-			if w, err := strconv.Atoi(os.Args[2]); err == nil && w > 0 && w <= 10 {
-				maxWorkers = w
-			}
+// natsV1HandleLookupBatch mirrors POST /lookup via wilayahService.Lookup.
+func natsV1HandleLookupBatch(msg *nats.Msg) {
+	var req struct {
+		Codes []string `json:"codes"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil || len(req.Codes) == 0 {
+		v1RespondError(msg, "codes must not be empty")
+		return
+	}
+	v1Respond(msg, loadService().Lookup(req.Codes))
+}
+
+// natsV1HandleHealth lets a NATS-only consumer check the service is up
+// without an HTTP round trip, mirroring GET /api/v1/health's intent.
+func natsV1HandleHealth(msg *nats.Msg) {
+	v1Respond(msg, fiber.Map{"status": "ok"})
+}
+
+// natsQueueGroup returns the configured queue group for all NATS
+// subscriptions (via NATS_QUEUE_GROUP), or "" for ordinary fan-out
+// subscriptions. Subscribers sharing a queue group split a subject's
+// messages across the group instead of every member getting every message,
+// which is what lets this transport scale horizontally.
+func natsQueueGroup() string {
+	return strings.TrimSpace(os.Getenv("NATS_QUEUE_GROUP"))
+}
+
+// natsSubscribeAll subscribes every subject in subjects on conn, using
+// queue-group subscriptions when queue is non-empty.
+func natsSubscribeAll(conn *nats.Conn, subjects map[string]nats.MsgHandler, queue string) {
+	for subject, handler := range subjects {
+		var err error
+		if queue != "" {
+			_, err = conn.QueueSubscribe(subject, queue, handler)
+		} else {
+			_, err = conn.Subscribe(subject, handler)
+		}
+		if err != nil {
+			log.Printf("nats: failed to subscribe to %s: %v", subject, err)
 		}
-		runScraper(maxWorkers)
+	}
+}
 
-	case "help", "--help", "-h":
-		scraper.ShowHelp()
+// startNATS connects to NATS_URL and registers the request/reply handlers in
+// natsSubjects and natsV1Subjects. It's opt-in: callers only invoke it when
+// NATS_URL is set, and a connection failure is logged rather than fatal so
+// the HTTP API still comes up on its own. NATS_QUEUE_GROUP, if set, makes
+// every subscription a queue subscription so multiple instances of this
+// service can share the load instead of each replying to every request.
+func startNATS(url string) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		log.Printf("nats: failed to connect to %s: %v", url, err)
+		return
+	}
 
-	default:
-		fmt.Printf("‚ùå Perintah tidak dikenal: %s\n", command)
-		fmt.Println("Gunakan 'help' untuk melihat perintah yang tersedia.")
-		scraper.ShowHelp()
+	queue := natsQueueGroup()
+	natsSubscribeAll(conn, natsSubjects, queue)
+	natsSubscribeAll(conn, natsV1Subjects, queue)
+
+	natsConn = conn
+	total := len(natsSubjects) + len(natsV1Subjects)
+	if queue != "" {
+		log.Printf("nats: connected to %s, mirroring HTTP API on %d subjects (queue group %q)", url, total, queue)
+	} else {
+		log.Printf("nats: connected to %s, mirroring HTTP API on %d subjects", url, total)
 	}
 }
 
@@ -1265,6 +3747,22 @@ func runAPI(port string) {
 		log.Printf("üîë Using custom API Key from environment variable")
 	}
 
+	// SEARCH_MAX_TIMEOUT caps how long a client's ?timeout= param may push
+	// out a single request's deadline, e.g. "500ms" or "2s".
+	if raw := os.Getenv("SEARCH_MAX_TIMEOUT"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			searchMaxTimeout = d
+		} else {
+			log.Printf("search: ignoring invalid SEARCH_MAX_TIMEOUT %q", raw)
+		}
+	}
+
+	// Optional Prometheus /metrics server, same opt-in pattern as the
+	// scrape subcommand's --metrics-addr.
+	if metricsAddr := os.Getenv("METRICS_ADDR"); metricsAddr != "" {
+		metrics.Serve(metricsAddr)
+	}
+
 	// Load wilayah data
 	if err := loadWilayahData(); err != nil {
 		log.Fatal("Failed to load wilayah data:", err)
@@ -1273,11 +3771,40 @@ func runAPI(port string) {
 	// Build search index
 	buildSearchIndex()
 
-	// Initialize global scraper
-	globalScraper = scraper.NewScraper(scraper.ScraperConfig{
-		MaxWorkers: 4,
-		OutputDir:  "scraper/output",
-	})
+	// Load province/city nickname aliases used by the address parser
+	loadAddressAliases()
+
+	// Optional NATS request/reply transport mirroring the HTTP API
+	if natsURL := os.Getenv("NATS_URL"); natsURL != "" {
+		startNATS(natsURL)
+		if natsConn != nil {
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				log.Println("nats: draining connection before shutdown")
+				natsConn.Drain()
+			}()
+		}
+	}
+
+	// Initialize the scraper job manager
+	jm, err := scraper.NewJobManager("scraper/output")
+	if err != nil {
+		log.Fatal("Failed to initialize scraper job manager:", err)
+	}
+	globalJobManager = jm
+
+	// Load extraction rule sets so operators can target a new upstream
+	// source via POST /scraper/jobs{rule_set} without recompiling.
+	rulesDir := "scraper/rules"
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		log.Printf("⚠️  Failed to create rules dir %s: %v", rulesDir, err)
+	} else if rm, err := rules.NewManager(rulesDir); err != nil {
+		log.Printf("⚠️  Failed to load rule sets from %s: %v", rulesDir, err)
+	} else {
+		globalJobManager.SetRulesManager(rm)
+	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -1303,23 +3830,43 @@ func runAPI(port string) {
 	// API routes
 	api := app.Group("/api/v1")
 
+	// ETag/Last-Modified/304 against the current dataset snapshot, for
+	// every GET under /api/v1.
+	api.Use(cacheMiddleware)
+
 	// Health check
 	api.Get("/health", healthCheck)
 
+	// Dataset version, for clients deciding whether to invalidate their cache
+	api.Get("/version", getVersion)
+
 	// Statistics
 	api.Get("/stats", getStats)
 
 	// Search
 	api.Get("/search", searchWilayah)
-
-	// Wilayah endpoints
-	api.Get("/provinsi", getProvinsi)
-	api.Get("/kabupaten", getKabupaten)
-	api.Get("/kecamatan", getKecamatan)
-	api.Get("/desa", getDesa)
+	api.Get("/suggest", suggestWilayah)
+	api.Post("/parse", parseAddressWilayah)
+
+	// Wilayah endpoints (list endpoints also get a day-long Cache-Control,
+	// since the dataset only changes on an explicit reload)
+	api.Get("/provinsi", listCacheControl, getProvinsi)
+	api.Get("/kabupaten", listCacheControl, getKabupaten)
+	api.Post("/kabupaten/batch", batchKabupaten)
+	api.Get("/kecamatan", listCacheControl, getKecamatan)
+	api.Post("/kecamatan/batch", batchKecamatan)
+	api.Get("/desa", listCacheControl, getDesa)
+	api.Post("/desa/batch", batchDesa)
 
 	// Info endpoint with code parameter
 	api.Get("/info/:code", getWilayahInfo)
+	api.Post("/info/batch", batchResolveWilayah)
+	api.Post("/lookup", lookupWilayah)
+
+	// Admin endpoints (protected with the same API key as scraper control)
+	adminGroup := api.Group("/admin")
+	adminGroup.Use(apiKeyMiddleware)
+	adminGroup.Post("/reload", reloadDataset)
 
 	// Scraper control endpoints (protected with API key)
 	scraperGroup := api.Group("/scraper")
@@ -1329,7 +3876,14 @@ func runAPI(port string) {
 	scraperGroup.Post("/stop", stopScraper)
 	scraperGroup.Get("/status", getScraperStatus)
 	scraperGroup.Get("/progress", getScraperProgress)
+	scraperGroup.Get("/events", getScraperEvents)
 	scraperGroup.Get("/info", getAPIInfo) // Add API info endpoint
+	scraperGroup.Post("/jobs", postScraperJob)
+	scraperGroup.Get("/jobs", listScraperJobs)
+	scraperGroup.Get("/jobs/:id", getScraperJob)
+	scraperGroup.Delete("/jobs/:id", deleteScraperJob)
+	scraperGroup.Get("/rules", listScraperRules)
+	scraperGroup.Post("/rules/reload", reloadScraperRules)
 
 	// Documentation endpoint
 	api.Get("/", func(c *fiber.Ctx) error {
@@ -1384,17 +3938,323 @@ func runAPI(port string) {
 	log.Fatal(app.Listen(":" + port))
 }
 
-func runScraper(maxWorkers int) {
+// configFlagValue scans args for --config/--config=VALUE before the main
+// flag.FlagSet is built, since the config file's own values seed that
+// FlagSet's defaults (e.g. --rps defaults to wilayah.yml's rate_limit).
+func configFlagValue(args []string, fallback string) string {
+	for i, a := range args {
+		if a == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(a, "--config=") {
+			return strings.TrimPrefix(a, "--config=")
+		}
+	}
+	return fallback
+}
+
+// profileFlagValue scans args for --profile/--profile=VALUE before the main
+// flag.FlagSet is built, since the chosen profile's values seed that
+// FlagSet's defaults (e.g. --rps defaults to the profile's rate_limit).
+func profileFlagValue(args []string) string {
+	for i, a := range args {
+		if a == "--profile" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(a, "--profile=") {
+			return strings.TrimPrefix(a, "--profile=")
+		}
+	}
+	return ""
+}
+
+// applyYearOverride replaces every source's Years with the comma-separated
+// list from --year, e.g. "2023,2024,2025", so a one-off run can archive
+// historical snapshots without editing wilayah.yml.
+func applyYearOverride(sources []config.Source, yearsCSV string) []config.Source {
+	var years []int
+	for _, part := range strings.Split(yearsCSV, ",") {
+		if y, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			years = append(years, y)
+		}
+	}
+	if len(years) == 0 {
+		return sources
+	}
+
+	overridden := make([]config.Source, len(sources))
+	for i, src := range sources {
+		src.Years = years
+		overridden[i] = src
+	}
+	return overridden
+}
+
+// buildProvider resolves the --provider flag into a concrete provider.Provider:
+// sipedas hits the live API (the default), seed replays a wilayah_final_*.json
+// snapshot offline, and merge reconciles the two so discrepancies get logged
+// instead of silently picked.
+func buildProvider(kind, seedFile, baseURL string, rps float64, maxRetries int, backoffBase time.Duration) (provider.Provider, error) {
+	switch kind {
+	case "", "sipedas":
+		return nil, nil // NewScraper builds the default SipedasProvider itself.
+	case "seed":
+		if seedFile == "" {
+			return nil, fmt.Errorf("--seed-file is required for --provider=seed")
+		}
+		return provider.NewSeedProvider(seedFile)
+	case "merge":
+		if seedFile == "" {
+			return nil, fmt.Errorf("--seed-file is required for --provider=merge")
+		}
+		seed, err := provider.NewSeedProvider(seedFile)
+		if err != nil {
+			return nil, err
+		}
+		client := fetcher.NewClient(fetcher.Config{RPS: rps, MaxRetries: maxRetries, BackoffBase: backoffBase, OnRetry: metrics.RecordRetry})
+		sipedas := provider.NewSipedasProvider(baseURL, client)
+		return provider.NewMergeProvider(sipedas, seed, func(level, id, primaryNama, secondaryNama string) {
+			log.Printf("⚠️  %s %s: sipedas=%q seed=%q (using sipedas)", level, id, primaryNama, secondaryNama)
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown --provider %q (want sipedas, seed, or merge)", kind)
+	}
+}
+
+func runScraper(baseURL string, year int, outputDir string, maxWorkers int, rps float64, maxRetries int, backoffBase time.Duration, format string, progressMode progress.Mode, providerKind, seedFile string, only, exclude []string) {
+	prov, err := buildProvider(providerKind, seedFile, baseURL, rps, maxRetries, backoffBase)
+	if err != nil {
+		log.Printf("❌ Error setting up provider: %v", err)
+		return
+	}
+
 	s := scraper.NewScraper(scraper.ScraperConfig{
-		MaxWorkers: maxWorkers,
-		OutputDir:  "scraper/output",
+		MaxWorkers:  maxWorkers,
+		OutputDir:   outputDir,
+		BaseURL:     baseURL,
+		Year:        year,
+		RPS:         rps,
+		MaxRetries:  maxRetries,
+		BackoffBase: backoffBase,
+		Progress:    progressMode,
+		Provider:    prov,
+		Only:        only,
+		Exclude:     exclude,
 	})
 
 	s.SetupSignalHandler()
 
 	if err := s.ScrapeAll(); err != nil {
 		log.Printf("‚ùå Error during scraping: %v", err)
+		return
+	}
+
+	finalFile := s.FinalFilePath()
+	if finalFile == "" {
+		return
+	}
+	for _, f := range strings.Split(format, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" || f == "json" {
+			continue
+		}
+		outputFile := strings.TrimSuffix(finalFile, filepath.Ext(finalFile)) + "." + f
+		if err := convertWilayahFile(finalFile, outputFile, f); err != nil {
+			log.Printf("❌ Error converting to %s: %v", f, err)
+		}
+	}
+}
+
+// runScraperIncremental loads the most recent wilayah_final_*.json as a
+// baseline, re-scrapes only the kabupaten whose kecamatan id/nama set no
+// longer matches the hash stored in baseline, and writes a
+// wilayah_diff_YYYYMMDD.json describing what moved.
+func runScraperIncremental(baseURL string, year int, outputDir string, maxWorkers int, rps float64, maxRetries int, backoffBase time.Duration, progressMode progress.Mode, providerKind, seedFile string, only, exclude []string) {
+	prov, err := buildProvider(providerKind, seedFile, baseURL, rps, maxRetries, backoffBase)
+	if err != nil {
+		log.Printf("❌ Error setting up provider: %v", err)
+		return
+	}
+
+	s := scraper.NewScraper(scraper.ScraperConfig{
+		MaxWorkers:  maxWorkers,
+		OutputDir:   outputDir,
+		BaseURL:     baseURL,
+		Year:        year,
+		RPS:         rps,
+		MaxRetries:  maxRetries,
+		BackoffBase: backoffBase,
+		Progress:    progressMode,
+		Provider:    prov,
+		Only:        only,
+		Exclude:     exclude,
+	})
+
+	s.SetupSignalHandler()
+
+	baseline, err := s.LoadLatestFinal()
+	if err != nil {
+		log.Printf("❌ Error loading baseline: %v", err)
+		return
+	}
+
+	newData, err := s.ScrapeIncremental(baseline)
+	if err != nil {
+		log.Printf("❌ Error during incremental scrape: %v", err)
+		return
+	}
+
+	result := diff.Compute(baseline, newData)
+	diffFile := filepath.Join(outputDir, fmt.Sprintf("wilayah_diff_%s.json", time.Now().Format("20060102")))
+	if err := writeJSONFile(diffFile, result); err != nil {
+		log.Printf("❌ Error writing diff file: %v", err)
+		return
+	}
+	fmt.Printf("✅ Incremental selesai, diff disimpan: %s\n", diffFile)
+}
+
+// runScraperWatch runs as a foreground daemon: it watches outputDir (or
+// seedFile, if set) for a new wilayah_final_*.json baseline and kicks off an
+// incremental scrape plus a wilayah_diff_YYYYMMDD.json against it each time
+// one is dropped in. Ctrl+C stops it the same way it stops a normal scrape.
+func runScraperWatch(baseURL string, year int, outputDir string, maxWorkers int, rps float64, maxRetries int, backoffBase time.Duration, progressMode progress.Mode, providerKind, seedFile string, only, exclude []string) {
+	prov, err := buildProvider(providerKind, seedFile, baseURL, rps, maxRetries, backoffBase)
+	if err != nil {
+		log.Printf("❌ Error setting up provider: %v", err)
+		return
+	}
+
+	s := scraper.NewScraper(scraper.ScraperConfig{
+		MaxWorkers:  maxWorkers,
+		OutputDir:   outputDir,
+		BaseURL:     baseURL,
+		Year:        year,
+		RPS:         rps,
+		MaxRetries:  maxRetries,
+		BackoffBase: backoffBase,
+		Progress:    progressMode,
+		Provider:    prov,
+		Only:        only,
+		Exclude:     exclude,
+	})
+	s.SetupSignalHandler()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	watchLogger := logging.NewJSON(os.Stderr)
+	err = scraper.WatchSeed(ctx, outputDir, seedFile, watchLogger, func(path string) {
+		baseline, err := s.LoadLatestFinal()
+		if err != nil {
+			log.Printf("❌ Error loading baseline: %v", err)
+			return
+		}
+
+		newData, err := s.ScrapeIncremental(baseline)
+		if err != nil {
+			log.Printf("❌ Error during incremental scrape: %v", err)
+			return
+		}
+
+		result := diff.Compute(baseline, newData)
+		diffFile := filepath.Join(outputDir, fmt.Sprintf("wilayah_diff_%s.json", time.Now().Format("20060102")))
+		if err := writeJSONFile(diffFile, result); err != nil {
+			log.Printf("❌ Error writing diff file: %v", err)
+			return
+		}
+		fmt.Printf("✅ Incremental selesai, diff disimpan: %s\n", diffFile)
+	})
+	if err != nil {
+		log.Printf("❌ Error watching for new baseline: %v", err)
+	}
+}
+
+// runDiff compares two previously scraped wilayah_final_*.json files and
+// prints the added/removed/renamed entries at each level.
+func runDiff(oldFile, newFile string) {
+	oldData, err := loadWilayahFile(oldFile)
+	if err != nil {
+		fmt.Printf("❌ Error membaca %s: %v\n", oldFile, err)
+		os.Exit(1)
+	}
+	newData, err := loadWilayahFile(newFile)
+	if err != nil {
+		fmt.Printf("❌ Error membaca %s: %v\n", newFile, err)
+		os.Exit(1)
+	}
+
+	result := diff.Compute(oldData, newData)
+	diffFile := fmt.Sprintf("wilayah_diff_%s.json", time.Now().Format("20060102"))
+	if err := writeJSONFile(diffFile, result); err != nil {
+		fmt.Printf("❌ Error writing diff file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Diff disimpan: %s\n", diffFile)
+}
+
+func loadWilayahFile(path string) (*scraper.WilayahData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var data scraper.WilayahData
+	if err := json.NewDecoder(file).Decode(&data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
+
+// runConvert reads an existing wilayah_final_*.json file and writes it out
+// in the requested format, defaulting the output path next to the input.
+func runConvert(inputFile, outputFile, format string) {
+	if outputFile == "" {
+		ext := filepath.Ext(inputFile)
+		outputFile = strings.TrimSuffix(inputFile, ext) + "." + format
+	}
+
+	if err := convertWilayahFile(inputFile, outputFile, format); err != nil {
+		fmt.Printf("❌ Error converting file: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ File berhasil dikonversi: %s\n", outputFile)
+}
+
+// convertWilayahFile loads a wilayah_final_*.json file and re-emits it in
+// format via the internal/exporter package.
+func convertWilayahFile(inputFile, outputFile, format string) error {
+	data, err := loadWilayahFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %v", inputFile, err)
+	}
+
+	exp, err := exporter.New(format)
+	if err != nil {
+		return err
 	}
+
+	return exp.Export(data, outputFile)
 }
 
 func runScraperInfo() {
@@ -1404,6 +4264,54 @@ func runScraperInfo() {
 	s.ShowCheckpointInfo()
 }
 
+func runScraperRuleSet(rulesDir, name string, dryRun bool) {
+	rm, err := rules.NewManager(rulesDir)
+	if err != nil {
+		fmt.Printf("❌ Error loading rule sets from %s: %v\n", rulesDir, err)
+		os.Exit(1)
+	}
+
+	rs, ok := rm.Get(name)
+	if !ok {
+		fmt.Printf("❌ Rule set %q not found in %s\n", name, rulesDir)
+		os.Exit(1)
+	}
+
+	captures, err := rules.Execute(context.Background(), http.DefaultClient, rs.Root, rules.Capture{})
+	if err != nil {
+		fmt.Printf("❌ Error executing rule set %q: %v\n", name, err)
+		os.Exit(1)
+	}
+
+	if dryRun {
+		sample := captures
+		if len(sample) > dryRunCaptureSample {
+			sample = sample[:dryRunCaptureSample]
+		}
+		fmt.Printf("🔍 Dry run: %d captures (showing %d)\n", len(captures), len(sample))
+		for _, c := range sample {
+			fmt.Printf("   %v\n", c)
+		}
+		return
+	}
+
+	outputFile := filepath.Join("scraper/output", fmt.Sprintf("%s_captures_%s.json", name, time.Now().Format("20060102_150405")))
+	data, err := json.MarshalIndent(captures, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Error encoding captures: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		fmt.Printf("❌ Error writing %s: %v\n", outputFile, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Wrote %d captures to %s\n", len(captures), outputFile)
+}
+
+// dryRunCaptureSample caps how many captures the rule-set CLI's --dry-run
+// prints, matching JobManager's dryRunSampleSize for the HTTP job path.
+const dryRunCaptureSample = 10
+
 func runScraperClean(days int) {
 	s := scraper.NewScraper(scraper.ScraperConfig{
 		OutputDir: "scraper/output",
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeltaVarintRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		ids  []uint32
+	}{
+		{"empty", []uint32{}},
+		{"single", []uint32{42}},
+		{"consecutive", []uint32{0, 1, 2, 3, 4}},
+		{"sparse", []uint32{3, 100, 101, 5000, 5001, 1 << 20}},
+		{"large gaps", []uint32{0, 1 << 31, 1<<32 - 1}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			encoded := encodeDeltaVarint(c.ids)
+			decoded := decodeDeltaVarint(encoded)
+			if !reflect.DeepEqual(decoded, c.ids) {
+				t.Fatalf("round-trip mismatch: got %v, want %v", decoded, c.ids)
+			}
+		})
+	}
+}
+
+func TestSearchCandidatesFiltersByJaccardThreshold(t *testing.T) {
+	names := map[uint32]string{
+		0: "bandung",
+		1: "bandungbarat",
+		2: "jakarta",
+	}
+
+	b := newTrigramBuilder()
+	for id, name := range names {
+		b.add(name, id)
+	}
+	idx := b.build()
+
+	candidates := searchCandidates(idx, "bandung", func(rowID uint32) string {
+		return names[rowID]
+	})
+
+	got := make(map[uint32]bool, len(candidates))
+	for _, c := range candidates {
+		if c.Jaccard < searchTrigramJaccardThreshold {
+			t.Fatalf("candidate %+v below threshold %v slipped through", c, searchTrigramJaccardThreshold)
+		}
+		got[c.RowID] = true
+	}
+	if !got[0] {
+		t.Fatalf("expected exact name %q to be a candidate, got %+v", names[0], candidates)
+	}
+	if got[2] {
+		t.Fatalf("expected unrelated name %q to be filtered out, got %+v", names[2], candidates)
+	}
+}